@@ -0,0 +1,45 @@
+package main
+
+// accuracy of long calculations. accuracy on carries the rounding errors of +, -, × and ÷ along current
+// with compensated summation and fused multiply-add, and accuracy tells the rounding error accumulated.
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"gitlab.com/atthoriq/calculator-project/calculator"
+)
+
+const (
+	accuracyOp = "accuracy"
+
+	accuracyOn  = "on"
+	accuracyOff = "off"
+)
+
+// handleAccuracy tells the accumulated rounding error or switches compensated arithmetic on or off
+func (ch *calculatorHandler) handleAccuracy(args []string) (string, error) {
+	switch {
+	case len(args) == 0:
+		a := ch.calculator.GetAccuracy()
+		if !a.Compensated {
+			return "accuracy off: rounding error ≈ " + ch.formatError(a.Roundoff), nil
+		}
+		return "accuracy on: rounding error ≈ " + ch.formatError(a.Roundoff) + " compensated, " + ch.formatError(a.Compensation) + " left", nil
+	case len(args) > 1 || (args[0] != accuracyOn && args[0] != accuracyOff):
+		return "", errInvalidInput
+	}
+
+	if args[0] == accuracyOn && ch.calculator.GetPrecision() == calculator.Float32 {
+		return "", errors.New("accuracy on needs float64 precision")
+	}
+
+	ch.calculator.SetCompensated(args[0] == accuracyOn)
+	return "accuracy " + args[0], nil
+}
+
+// formatError writes an error with 2 significant digits, whatever the result format
+func (ch *calculatorHandler) formatError(e float64) string {
+	return ch.locale.localize(strconv.FormatFloat(math.Abs(e), 'g', 2, 64))
+}
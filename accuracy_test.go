@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_calculatorHandler_Handle_Accuracy(t *testing.T) {
+	tenths := []string{"format fixed 17"}
+	for i := 0; i < 10; i++ {
+		tenths = append(tenths, "add 0.1")
+	}
+
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "plain sum",
+			commands: tenths,
+			want:     "0.99999999999999989",
+		},
+		{
+			name:     "compensated sum",
+			commands: append([]string{"accuracy on"}, tenths...),
+			want:     "1.00000000000000000",
+		},
+		{
+			name:     "large and small terms",
+			commands: []string{"accuracy on", "format auto", "add 1e16", "add 1", "add 1", "subtract 1e16"},
+			want:     "2",
+		},
+		{
+			name:     "switch on",
+			commands: []string{"accuracy on"},
+			want:     "accuracy on",
+		},
+		{
+			name:     "rounding error",
+			commands: append(tenths, "accuracy"),
+			want:     "accuracy off: rounding error ≈ 2.2e-16",
+		},
+		{
+			name:     "compensated rounding error",
+			commands: []string{"accuracy on", "add 1e16", "add 1", "accuracy"},
+			want:     "accuracy on: rounding error ≈ 1 compensated, 1 left",
+		},
+		{
+			name:     "exact operations",
+			commands: []string{"add 0.5", "multiply 4", "accuracy"},
+			want:     "accuracy off: rounding error ≈ 0",
+		},
+		{
+			name:     "float32",
+			commands: []string{"precision float32", "accuracy on"},
+			wantErr:  "accuracy on needs float64 precision",
+		},
+		{
+			name:     "go export",
+			commands: []string{"accuracy on", "add 0.1", "add 0.2", "add 0.3", "export go sum"},
+			wantErr:  "go export doesn't reproduce the compensated sums of accuracy on",
+		},
+		{
+			name:     "go export once switched off",
+			commands: []string{"accuracy on", "add 1e16", "add 1", "add 1", "accuracy off", "export go sum"},
+			wantErr:  "go export doesn't reproduce the compensated sums of accuracy on",
+		},
+		{
+			name:     "go export after cancel",
+			commands: []string{"accuracy on", "add 0.1", "add 0.2", "add 0.3", "cancel", "add 0.5", "export go half"},
+			want:     "// file: half.go\npackage main\n\n// half reproduces the operations recorded by the calculator.\n// multiplications are converted explicitly to float64 so they are never fused into an FMA,\n// which keeps the result identical to the calculator.\nfunc half(x float64) float64 {\n\tx = x + 0.5\n\treturn x\n}\n\n// file: half_test.go\npackage main\n\nimport (\n\t\"math\"\n\t\"testing\"\n)\n\nfunc TestHalf(t *testing.T) {\n\tgot := half(0)\n\twant := float64(0.5)\n\tif got != want && !(math.IsNaN(got) && math.IsNaN(want)) {\n\t\tt.Errorf(\"half() = %v, want %v\", got, want)\n\t}\n}\n",
+		},
+		{
+			name:     "unknown setting",
+			commands: []string{"accuracy high"},
+			wantErr:  errInvalidInput.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleAll(t, tt.commands)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
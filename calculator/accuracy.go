@@ -0,0 +1,91 @@
+package calculator
+
+// accuracy of long calculations. every +, -, × and ÷ rounds its result, and the rounding error of each of them
+// is known exactly: TwoSum for a sum and a fused multiply-add for a product or a quotient. the errors add up in
+// an estimate of the roundoff, and when compensated they are carried along current so long add chains stay exact
+// to the last bit, the way Neumaier's compensated summation does.
+
+import "math"
+
+// Accuracy is the rounding error accumulated since the last cancel. Roundoff estimates how far plain float64
+// arithmetic drifted from the exact result, Compensation is what current misses of the compensated value
+type Accuracy struct {
+	Compensated  bool
+	Roundoff     float64
+	Compensation float64
+}
+
+// SetCompensated switches compensated arithmetic on or off. it only applies to float64 precision
+func (c *newCalculator) SetCompensated(on bool) {
+	// clean hold operations
+	c.GetResult()
+
+	c.compensated = on
+	c.compensation = 0
+}
+
+func (c *newCalculator) GetAccuracy() Accuracy {
+	// clean hold operations
+	c.GetResult()
+
+	return Accuracy{Compensated: c.compensated, Roundoff: c.roundoff, Compensation: c.compensation}
+}
+
+// compensate accounts the rounding error of the operation just applied on before. other operations than
+// +, -, × and ÷ aren't accounted and drop the compensation, current being already its rounding.
+// it tells whether the compensation moved current off the plain float64 result
+func (c *newCalculator) compensate(op operation, before float64) bool {
+	if !isFinite(before) || !isFinite(c.current) {
+		c.compensation = 0
+		return false
+	}
+
+	switch op.name {
+	case addOp, subtractOp:
+		a := op.args[0]
+		if op.name == subtractOp {
+			a = -a
+		}
+		err := sumError(before, a, c.current)
+		c.roundoff += math.Abs(err)
+		c.compensation += err
+	case multiplyOp:
+		a := op.args[0]
+		err := math.FMA(before, a, -c.current)
+		c.roundoff = c.roundoff*math.Abs(a) + math.Abs(err)
+		c.compensation = c.compensation*a + err
+	case divideOp:
+		a := op.args[0]
+		// the remainder of the quotient is exact, so is the error once divided up to its own rounding
+		err := math.FMA(-c.current, a, before) / a
+		c.roundoff = c.roundoff/math.Abs(a) + math.Abs(err)
+		c.compensation = c.compensation/a + err
+	default:
+		c.compensation = 0
+		return false
+	}
+
+	if !c.compensated || c.precision == Float32 {
+		c.compensation = 0
+		return false
+	}
+
+	// fold the compensation into current, what doesn't fit in float64 stays in the compensation
+	sum := c.current + c.compensation
+	moved := sum != c.current
+	c.compensation = sumError(c.current, c.compensation, sum)
+	c.current = sum
+	return moved
+}
+
+// sumError is the exact rounding error of sum, the float64 sum of a and b, as in Neumaier's summation
+func sumError(a, b, sum float64) float64 {
+	if math.Abs(a) >= math.Abs(b) {
+		return (a - sum) + b
+	}
+	return (b - sum) + a
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
@@ -0,0 +1,100 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCalculator_Compensated(t *testing.T) {
+	tests := []struct {
+		name        string
+		compensated bool
+		calculate   func(c *newCalculator)
+		want        float64
+	}{
+		{
+			name:      "plain sum drifts",
+			calculate: func(c *newCalculator) { addTimes(c, 0.1, 10) },
+			want:      0.9999999999999999,
+		},
+		{
+			name:        "compensated sum",
+			compensated: true,
+			calculate:   func(c *newCalculator) { addTimes(c, 0.1, 10) },
+			want:        1,
+		},
+		{
+			name:        "small terms aren't swallowed",
+			compensated: true,
+			calculate:   func(c *newCalculator) { c.Add(1e16).Add(1).Add(1).Subtract(1e16) },
+			want:        2,
+		},
+		{
+			name:        "compensation goes through multiply",
+			compensated: true,
+			calculate:   func(c *newCalculator) { c.Add(1e16).Add(1).Multiply(3).Subtract(3e16) },
+			want:        3,
+		},
+		{
+			name:        "compensation goes through divide",
+			compensated: true,
+			calculate:   func(c *newCalculator) { c.Add(1).Divide(3).Multiply(3).Subtract(1) },
+			want:        0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := InitNewCalculator()
+			c.SetCompensated(tt.compensated)
+			tt.calculate(c)
+			assert.Equal(t, tt.want, c.GetResult())
+		})
+	}
+}
+
+func TestNewCalculator_GetAccuracy(t *testing.T) {
+	c := InitNewCalculator()
+	addTimes(c, 0.1, 10)
+	a := c.GetAccuracy()
+	assert.False(t, a.Compensated)
+	// the estimate bounds the drift of the plain sum
+	assert.GreaterOrEqual(t, a.Roundoff, 1-c.GetResult())
+	assert.Less(t, a.Roundoff, 1e-15)
+	assert.Zero(t, a.Compensation)
+
+	// exact operations don't add up
+	c.Cancel()
+	c.Add(0.5).Multiply(4).Divide(8)
+	assert.Zero(t, c.GetAccuracy().Roundoff)
+
+	c.SetCompensated(true)
+	c.Add(0.1).Add(0.2)
+	a = c.GetAccuracy()
+	assert.True(t, a.Compensated)
+	assert.NotZero(t, a.Roundoff)
+	assert.NotZero(t, a.Compensation)
+
+	c.Cancel()
+	assert.Equal(t, Accuracy{Compensated: true}, c.GetAccuracy())
+}
+
+func addTimes(c *newCalculator, a float64, n int) {
+	for i := 0; i < n; i++ {
+		c.Add(a)
+	}
+}
+
+func TestNewCalculator_CompensatedSteps(t *testing.T) {
+	c := InitNewCalculator()
+	c.SetCompensated(true)
+	c.Add(0.1).Add(0.2).Add(0.3)
+	c.SetCompensated(false)
+	c.Add(1)
+
+	var compensated []bool
+	for _, step := range c.GetHistory() {
+		compensated = append(compensated, step.Compensated)
+	}
+	assert.Equal(t, []bool{false, false, true, false}, compensated)
+}
@@ -0,0 +1,83 @@
+package calculator
+
+// bounds of current. an operand may be an interval, e.g. 10±0.5, and every operation computes the
+// interval holding current for every value of the operands next to the value of current itself.
+// operations without interval rule, like gamma or the integer functions, give up with the entire line.
+
+import (
+	"gitlab.com/atthoriq/calculator-project/interval"
+)
+
+// GetBounds returns the interval holding current, a single value when nothing is uncertain
+func (c *newCalculator) GetBounds() interval.Interval {
+	// clean hold operations
+	c.GetResult()
+
+	return c.bounds
+}
+
+// Within tells the operand of the last queued operation is anywhere in the interval x, e.g. Add(10).Within(interval.Around(10, 0.5)).
+// it does nothing when no operation is queued
+func (c *newCalculator) Within(x interval.Interval) NewCalculator {
+	if len(c.currentOperations) > 0 {
+		c.currentOperations[len(c.currentOperations)-1].operand = &x
+	}
+	return c
+}
+
+// operandBounds is the interval of the first argument of the operation
+func (o operation) operandBounds() interval.Interval {
+	if o.operand != nil {
+		return *o.operand
+	}
+	if len(o.args) == 0 {
+		return interval.Point(0)
+	}
+	return interval.Point(o.args[0])
+}
+
+// boundsAfter is the interval holding current once the operation is applied on a current within b
+func (o operation) boundsAfter(b interval.Interval, current float64) interval.Interval {
+	x := o.operandBounds()
+	if b.IsPoint() && x.IsPoint() {
+		return interval.Point(current)
+	}
+
+	var after interval.Interval
+	switch o.name {
+	case addOp:
+		after = b.Add(x)
+	case subtractOp:
+		after = b.Sub(x)
+	case multiplyOp:
+		after = b.Mul(x)
+	case divideOp:
+		after = b.Div(x)
+	case convertOp:
+		after = b.Scale(o.args[0]).Add(interval.Point(o.args[1]))
+	case exchangeOp:
+		after = b.Scale(o.args[0])
+	case absOp:
+		after = b.Abs()
+	case rootOp:
+		after = b.Root(int(o.args[0]))
+	case powOp:
+		after = b.Pow(o.args[0])
+	case addPercentOp, markupOp:
+		after = b.Scale(1 + o.args[0]/100)
+	case subtractPercentOp, discountOp:
+		after = b.Scale(1 - o.args[0]/100)
+	case percentOfOp:
+		after = b.Scale(o.args[0] / 100)
+	case marginOp:
+		after = b.Div(interval.Point(1 - o.args[0]/100))
+	case percentChangeOp:
+		// (x - current) / current * 100 written with a single current, so the interval stays tight
+		after = x.Div(b).Sub(interval.Point(1)).Scale(100)
+	default:
+		return interval.Entire()
+	}
+
+	// current is within its bounds even when its rounding differs from the one of the bounds
+	return after.Hull(current)
+}
@@ -0,0 +1,108 @@
+package calculator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/atthoriq/calculator-project/interval"
+)
+
+func TestNewCalculator_Bounds(t *testing.T) {
+	inf := math.Inf(1)
+
+	tests := []struct {
+		name      string
+		calculate func(c *newCalculator)
+		lo, hi    float64
+	}{
+		{
+			name:      "no uncertainty",
+			calculate: func(c *newCalculator) { c.Add(10).Multiply(2) },
+			lo:        20,
+			hi:        20,
+		},
+		{
+			name: "add and subtract sum the errors",
+			calculate: func(c *newCalculator) {
+				c.Add(10).Within(interval.Around(10, 0.5)).Subtract(2).Within(interval.Around(2, 0.1))
+			},
+			lo: 7.4,
+			hi: 8.6,
+		},
+		{
+			name: "multiply",
+			calculate: func(c *newCalculator) {
+				c.Add(10).Within(interval.Around(10, 1)).Multiply(2).Within(interval.Around(2, 0.5))
+			},
+			lo: 13.5,
+			hi: 27.5,
+		},
+		{
+			name:      "divide through zero",
+			calculate: func(c *newCalculator) { c.Add(1).Divide(1).Within(interval.New(-1, 3)) },
+			lo:        -inf,
+			hi:        inf,
+		},
+		{
+			name:      "square root clips the domain",
+			calculate: func(c *newCalculator) { c.Add(0).Within(interval.New(-1, 4)).Root(2) },
+			lo:        0,
+			hi:        2,
+		},
+		{
+			name:      "even power",
+			calculate: func(c *newCalculator) { c.Add(1).Within(interval.New(-3, 2)).Pow(2) },
+			lo:        0,
+			hi:        9,
+		},
+		{
+			name:      "percent scales the bounds",
+			calculate: func(c *newCalculator) { c.Add(100).Within(interval.Around(100, 10)).AddPercent(10) },
+			lo:        99,
+			hi:        121,
+		},
+		{
+			name:      "repeat applies the bounds again",
+			calculate: func(c *newCalculator) { c.Add(10).Within(interval.Around(10, 1)).Repeat(1) },
+			lo:        18,
+			hi:        22,
+		},
+		{
+			name:      "no rule gives up",
+			calculate: func(c *newCalculator) { c.Add(3).Within(interval.Around(3, 0.1)).Gamma() },
+			lo:        -inf,
+			hi:        inf,
+		},
+		{
+			name:      "invert restores the bounds",
+			calculate: func(c *newCalculator) { c.Add(3).Within(interval.Around(3, 0.1)).Multiply(2).Add(1); c.Invert() },
+			lo:        0,
+			hi:        0,
+		},
+		{
+			name:      "cancel clears the bounds",
+			calculate: func(c *newCalculator) { c.Add(3).Within(interval.Around(3, 0.1)).Cancel() },
+			lo:        0,
+			hi:        0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := InitNewCalculator()
+			tt.calculate(c)
+			got := c.GetBounds()
+			assert.InDelta(t, tt.lo, got.Lo, 1e-9)
+			assert.InDelta(t, tt.hi, got.Hi, 1e-9)
+			assert.True(t, got.Contains(c.GetResult()))
+		})
+	}
+}
+
+func TestNewCalculator_Within_NothingQueued(t *testing.T) {
+	c := InitNewCalculator()
+	c.Add(5)
+	c.GetResult()
+	c.Within(interval.Around(5, 1))
+	assert.True(t, c.GetBounds().IsPoint())
+}
@@ -0,0 +1,105 @@
+package calculator
+
+// factorial, gamma and combinatorics operations. factorials and combinations need integer current
+// and arguments, anything else makes the result NaN. results beyond float64 are +Inf.
+
+import (
+	"math"
+
+	"gitlab.com/atthoriq/calculator-project/combinatorics"
+	"gitlab.com/atthoriq/calculator-project/numtheory"
+)
+
+const (
+	factorialOp    = "fact"
+	gammaOp        = "gamma"
+	logGammaOp     = "lgamma"
+	chooseOp       = "ncr"
+	permutationsOp = "npr"
+	binomialOp     = "binom"
+
+	OpFactorial    = factorialOp
+	OpGamma        = gammaOp
+	OpLogGamma     = logGammaOp
+	OpChoose       = chooseOp
+	OpPermutations = permutationsOp
+	OpBinomial     = binomialOp
+)
+
+// Factorial is current!
+func (c *newCalculator) Factorial() NewCalculator {
+	c.currentOperations = append(c.currentOperations, operation{name: factorialOp, fn: func(nc *newCalculator) {
+		nc.ofInteger(func(n int64) float64 {
+			return combinatorics.FactorialFloat(n)
+		})
+	}})
+	return c
+}
+
+// Gamma is the gamma function of current, current-1 factorial for positive integers
+func (c *newCalculator) Gamma() NewCalculator {
+	c.currentOperations = append(c.currentOperations, operation{name: gammaOp, fn: func(nc *newCalculator) {
+		nc.current = math.Gamma(nc.current)
+	}})
+	return c
+}
+
+// LogGamma is the natural logarithm of the absolute gamma function of current
+func (c *newCalculator) LogGamma() NewCalculator {
+	c.currentOperations = append(c.currentOperations, operation{name: logGammaOp, fn: func(nc *newCalculator) {
+		nc.current, _ = math.Lgamma(nc.current)
+	}})
+	return c
+}
+
+// Choose is the number of combinations of k out of current
+func (c *newCalculator) Choose(k float64) NewCalculator {
+	c.currentOperations = append(c.currentOperations, operation{name: chooseOp, args: []float64{k}, fn: func(nc *newCalculator) {
+		nc.ofInteger(func(n int64) float64 {
+			k, err := numtheory.FromFloat(k)
+			if err != nil {
+				return math.NaN()
+			}
+			return combinatorics.ChooseFloat(n, k)
+		})
+	}})
+	return c
+}
+
+// Permutations is the number of ordered arrangements of k out of current
+func (c *newCalculator) Permutations(k float64) NewCalculator {
+	c.currentOperations = append(c.currentOperations, operation{name: permutationsOp, args: []float64{k}, fn: func(nc *newCalculator) {
+		nc.ofInteger(func(n int64) float64 {
+			k, err := numtheory.FromFloat(k)
+			if err != nil {
+				return math.NaN()
+			}
+			return combinatorics.PermutationsFloat(n, k)
+		})
+	}})
+	return c
+}
+
+// Binomial is the probability of exactly k successes out of current trials with a success probability p
+func (c *newCalculator) Binomial(k, p float64) NewCalculator {
+	c.currentOperations = append(c.currentOperations, operation{name: binomialOp, args: []float64{k, p}, fn: func(nc *newCalculator) {
+		nc.ofInteger(func(n int64) float64 {
+			k, err := numtheory.FromFloat(k)
+			if err != nil {
+				return math.NaN()
+			}
+			return combinatorics.Binomial(n, k, p)
+		})
+	}})
+	return c
+}
+
+// ofInteger sets current to fn of current read as integer, current becomes NaN when it isn't an integer
+func (c *newCalculator) ofInteger(fn func(n int64) float64) {
+	n, err := numtheory.FromFloat(c.current)
+	if err != nil {
+		c.current = math.NaN()
+		return
+	}
+	c.current = fn(n)
+}
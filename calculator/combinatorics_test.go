@@ -0,0 +1,67 @@
+package calculator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewCalculator_Combinatorics(t *testing.T) {
+	tests := []struct {
+		name      string
+		calculate func(c NewCalculator) NewCalculator
+		want      float64
+	}{
+		{
+			name:      "factorial",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(10).Factorial() },
+			want:      3628800,
+		},
+		{
+			name:      "factorial beyond float64 is +Inf",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(171).Factorial() },
+			want:      math.Inf(1),
+		},
+		{
+			name:      "factorial of a fraction is NaN",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(4.5).Factorial() },
+			want:      math.NaN(),
+		},
+		{
+			name:      "gamma",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(5).Gamma() },
+			want:      24,
+		},
+		{
+			name:      "log gamma",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(1).LogGamma() },
+			want:      0,
+		},
+		{
+			name:      "combinations",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(52).Choose(5) },
+			want:      2598960,
+		},
+		{
+			name:      "permutations",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(10).Permutations(3) },
+			want:      720,
+		},
+		{
+			name:      "binomial probability",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(5).Binomial(2, 0.5) },
+			want:      0.3125,
+		},
+		{
+			name:      "repeat factorial",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(3).Factorial().Repeat(1) },
+			want:      720,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.calculate(InitNewCalculator()).GetResult(); !floatEqual(got, tt.want) {
+				t.Errorf("Calculator.GetResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
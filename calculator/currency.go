@@ -0,0 +1,42 @@
+package calculator
+
+// currency of current. current can be tagged with a currency code and exchanged to another one, the
+// other operations keep it. as the exchange is an operation of its own, invert gets the currency back,
+// and repeat skips it as current is already in the currency exchanged to.
+
+const (
+	exchangeOp = "exchange"
+
+	OpExchange = exchangeOp
+)
+
+// SetCurrency tags current with the currency code without changing its value, an empty code clears it
+func (c *newCalculator) SetCurrency(code string) {
+	// clean hold operations
+	c.GetResult()
+
+	c.currency = code
+}
+
+func (c *newCalculator) GetCurrency() string {
+	// clean hold operations
+	c.GetResult()
+
+	return c.currency
+}
+
+// Exchange multiplies current by rate, the price of its currency in the currency code, and tags it with code
+func (c *newCalculator) Exchange(rate float64, code string) NewCalculator {
+	c.currentOperations = append(c.currentOperations, operation{name: exchangeOp, args: []float64{rate}, currency: code, fn: func(nc *newCalculator) {
+		nc.current *= rate
+	}})
+	return c
+}
+
+// currencyAfter is the currency of current once the operation is applied on a current of currency code
+func (o operation) currencyAfter(code string) string {
+	if o.name == exchangeOp {
+		return o.currency
+	}
+	return code
+}
@@ -0,0 +1,62 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCalculator_Currency(t *testing.T) {
+	tests := []struct {
+		name      string
+		calculate func(c *newCalculator)
+		want      float64
+		wantCode  string
+	}{
+		{
+			name: "exchange tags current",
+			calculate: func(c *newCalculator) {
+				c.SetCurrency("USD")
+				c.Add(100).Exchange(0.9, "EUR")
+			},
+			want:     90,
+			wantCode: "EUR",
+		},
+		{
+			name: "repeat skips exchange",
+			calculate: func(c *newCalculator) {
+				c.SetCurrency("USD")
+				c.Add(100).Exchange(0.5, "EUR").Repeat(2)
+			},
+			want:     150,
+			wantCode: "EUR",
+		},
+		{
+			name: "invert restores the currency",
+			calculate: func(c *newCalculator) {
+				c.SetCurrency("USD")
+				c.Add(100).Exchange(0.9, "EUR").Add(10)
+				c.Invert()
+			},
+			want:     0,
+			wantCode: "USD",
+		},
+		{
+			name: "cancel clears the currency",
+			calculate: func(c *newCalculator) {
+				c.SetCurrency("USD")
+				c.Add(100).Cancel()
+			},
+			want:     0,
+			wantCode: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := InitNewCalculator()
+			tt.calculate(c)
+			assert.InDelta(t, tt.want, c.GetResult(), 1e-9)
+			assert.Equal(t, tt.wantCode, c.GetCurrency())
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package calculator
+
+// dataset of the calculator, filled by the stats mode. it lives next to current and is left untouched by the operations.
+// it holds single values and x,y points apart from each other
+
+// Point is a pair of values of the dataset
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Push appends x to the dataset, rounded to the precision
+func (c *newCalculator) Push(x float64) {
+	c.data = append(c.data, c.round(x))
+}
+
+// GetData returns a copy of the dataset
+func (c *newCalculator) GetData() []float64 {
+	return append([]float64(nil), c.data...)
+}
+
+// PushPoint appends the point (x, y) to the dataset, rounded to the precision
+func (c *newCalculator) PushPoint(x, y float64) {
+	c.points = append(c.points, Point{c.round(x), c.round(y)})
+}
+
+// GetPoints returns a copy of the points of the dataset
+func (c *newCalculator) GetPoints() []Point {
+	return append([]Point(nil), c.points...)
+}
+
+// ClearData empties the values and the points of the dataset
+func (c *newCalculator) ClearData() {
+	c.data = nil
+	c.points = nil
+}
@@ -0,0 +1,428 @@
+package calculator
+
+// expression tree of the recorded history, written in terms of the starting value "x".
+// every operation wraps the previous expression into a new node. nodes are built through
+// small constructors (sum, product, ...) which simplify where it is safe to do so,
+// e.g. "x + 2 + 3" becomes "x + 5" and "cbrt(x^3)" becomes "x".
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Expression is a symbolic view of the calculation that can be exported as plain text or LaTeX
+type Expression interface {
+	String() string
+	LaTeX() string
+	// precedence is used to decide whether a child expression needs parentheses
+	precedence() int
+}
+
+const (
+	sumPrecedence = iota + 1
+	productPrecedence
+	powerPrecedence
+	atomPrecedence
+)
+
+type variable struct{}
+
+type constant struct {
+	value float64
+}
+
+// sum is "term + offset"
+type sum struct {
+	term   Expression
+	offset float64
+}
+
+// product is "term * factor" or "term / factor" when divide is true
+type product struct {
+	term   Expression
+	factor float64
+	divide bool
+}
+
+type power struct {
+	base     Expression
+	exponent float64
+}
+
+type root struct {
+	radicand Expression
+	degree   int
+}
+
+type absolute struct {
+	arg Expression
+}
+
+// quotient is "value / divisor"
+type quotient struct {
+	value   float64
+	divisor Expression
+}
+
+// function is a named function of an expression and constant parameters, e.g. "gcd(x, 12)"
+type function struct {
+	name   string
+	arg    Expression
+	params []float64
+}
+
+// expression returns e with the operation applied on top of it
+func (o operation) expression(e Expression) Expression {
+	if e == nil {
+		e = variable{}
+	}
+
+	switch o.name {
+	case addOp:
+		return newSum(e, o.args[0])
+	case subtractOp:
+		return newSum(e, -o.args[0])
+	case multiplyOp, exchangeOp:
+		return newProduct(e, o.args[0], false)
+	case divideOp:
+		return newProduct(e, o.args[0], true)
+	case convertOp:
+		return newSum(newProduct(e, o.args[0], false), o.args[1])
+	case absOp:
+		return newAbsolute(e)
+	case rootOp:
+		return newRoot(e, int(o.args[0]))
+	case powOp:
+		return newPower(e, o.args[0])
+	case addPercentOp, markupOp:
+		return newProduct(e, 1+o.args[0]/100, false)
+	case subtractPercentOp, discountOp:
+		return newProduct(e, 1-o.args[0]/100, false)
+	case percentOfOp:
+		return newProduct(e, o.args[0]/100, false)
+	case marginOp:
+		return newProduct(e, 1-o.args[0]/100, true)
+	case percentChangeOp:
+		// (a - e) / e * 100 = 100a / e - 100
+		return newSum(newQuotient(100*o.args[0], e), -100)
+	case gcdOp, lcmOp, nextPrimeOp, modPowOp, modInvOp,
+		factorialOp, gammaOp, logGammaOp, chooseOp, permutationsOp, binomialOp:
+		return newFunction(o, e)
+	default:
+		return e
+	}
+}
+
+func newSum(e Expression, offset float64) Expression {
+	if c, ok := e.(constant); ok {
+		return constant{c.value + offset}
+	}
+	if s, ok := e.(sum); ok {
+		return newSum(s.term, s.offset+offset)
+	}
+	if offset == 0 {
+		return e
+	}
+
+	return sum{e, offset}
+}
+
+func newProduct(e Expression, factor float64, divide bool) Expression {
+	if divide && factor == 0 {
+		return constant{math.NaN()}
+	}
+	if c, ok := e.(constant); ok {
+		if divide {
+			return constant{c.value / factor}
+		}
+		return constant{c.value * factor}
+	}
+	if !divide && factor == 0 {
+		return constant{0}
+	}
+	if p, ok := e.(product); ok && p.divide == divide {
+		return newProduct(p.term, p.factor*factor, divide)
+	}
+	if factor == 1 {
+		return e
+	}
+
+	return product{e, factor, divide}
+}
+
+func newPower(e Expression, exponent float64) Expression {
+	if c, ok := e.(constant); ok {
+		return constant{math.Pow(c.value, exponent)}
+	}
+	if exponent == 0 {
+		return constant{1}
+	}
+	if exponent == 1 {
+		return e
+	}
+	// (b^m)^n = b^(m*n) holds for every real b only when n is an integer
+	if p, ok := e.(power); ok && isInteger(exponent) {
+		return newPower(p.base, p.exponent*exponent)
+	}
+	// cbrt(b)^3 = b
+	if r, ok := e.(root); ok && r.degree == 3 && exponent == 3 {
+		return r.radicand
+	}
+
+	return power{e, exponent}
+}
+
+func newRoot(e Expression, degree int) Expression {
+	if degree != 2 && degree != 3 {
+		return constant{math.NaN()}
+	}
+	if c, ok := e.(constant); ok {
+		if degree == 2 {
+			return constant{math.Sqrt(c.value)}
+		}
+		return constant{math.Cbrt(c.value)}
+	}
+	if p, ok := e.(power); ok && p.exponent == float64(degree) {
+		// sqrt(b^2) = |b| and cbrt(b^3) = b
+		if degree == 2 {
+			return newAbsolute(p.base)
+		}
+		return p.base
+	}
+
+	return root{e, degree}
+}
+
+func newAbsolute(e Expression) Expression {
+	switch v := e.(type) {
+	case constant:
+		return constant{math.Abs(v.value)}
+	case absolute:
+		return v
+	case root:
+		if v.degree == 2 {
+			return v
+		}
+	case power:
+		if isInteger(v.exponent) && int64(v.exponent)%2 == 0 {
+			return v
+		}
+	}
+
+	return absolute{e}
+}
+
+func newQuotient(value float64, divisor Expression) Expression {
+	if c, ok := divisor.(constant); ok {
+		if c.value == 0 {
+			return constant{math.NaN()}
+		}
+		return constant{value / c.value}
+	}
+	if value == 0 {
+		return constant{0}
+	}
+
+	return quotient{value, divisor}
+}
+
+// newFunction folds a constant argument by applying the operation on it
+func newFunction(o operation, e Expression) Expression {
+	if c, ok := e.(constant); ok {
+		scratch := &newCalculator{current: c.value}
+		o.fn(scratch)
+		return constant{scratch.current}
+	}
+
+	return function{o.name, e, o.args}
+}
+
+func isInteger(f float64) bool {
+	return f == math.Trunc(f) && !math.IsInf(f, 0)
+}
+
+func (variable) String() string  { return "x" }
+func (variable) LaTeX() string   { return "x" }
+func (variable) precedence() int { return atomPrecedence }
+
+func (c constant) String() string { return formatNumber(c.value) }
+func (c constant) LaTeX() string  { return latexNumber(c.value) }
+func (c constant) precedence() int {
+	if c.value < 0 || strings.ContainsAny(formatNumber(c.value), "e") {
+		return sumPrecedence
+	}
+	return atomPrecedence
+}
+
+func (s sum) String() string {
+	if s.offset < 0 {
+		return fmt.Sprintf("%s - %s", s.term, formatNumber(-s.offset))
+	}
+	return fmt.Sprintf("%s + %s", s.term, formatNumber(s.offset))
+}
+
+func (s sum) LaTeX() string {
+	if s.offset < 0 {
+		return fmt.Sprintf("%s - %s", s.term.LaTeX(), latexNumber(-s.offset))
+	}
+	return fmt.Sprintf("%s + %s", s.term.LaTeX(), latexNumber(s.offset))
+}
+
+func (sum) precedence() int { return sumPrecedence }
+
+func (p product) String() string {
+	term := wrap(p.term.String(), p.term, productPrecedence)
+	factor := wrap(formatNumber(p.factor), constant{p.factor}, powerPrecedence)
+	if p.divide {
+		return fmt.Sprintf("%s / %s", term, factor)
+	}
+	return fmt.Sprintf("%s * %s", term, factor)
+}
+
+func (p product) LaTeX() string {
+	if p.divide {
+		return fmt.Sprintf(`\frac{%s}{%s}`, p.term.LaTeX(), latexNumber(p.factor))
+	}
+	term := wrapLaTeX(p.term.LaTeX(), p.term, productPrecedence)
+	factor := wrapLaTeX(latexNumber(p.factor), constant{p.factor}, powerPrecedence)
+	return fmt.Sprintf(`%s \cdot %s`, term, factor)
+}
+
+func (product) precedence() int { return productPrecedence }
+
+func (p power) String() string {
+	return fmt.Sprintf("%s^%s", wrap(p.base.String(), p.base, atomPrecedence), wrap(formatNumber(p.exponent), constant{p.exponent}, atomPrecedence))
+}
+
+func (p power) LaTeX() string {
+	return fmt.Sprintf("%s^{%s}", wrapLaTeX(p.base.LaTeX(), p.base, atomPrecedence), latexNumber(p.exponent))
+}
+
+func (power) precedence() int { return powerPrecedence }
+
+func (r root) String() string {
+	if r.degree == 3 {
+		return fmt.Sprintf("cbrt(%s)", r.radicand)
+	}
+	return fmt.Sprintf("sqrt(%s)", r.radicand)
+}
+
+func (r root) LaTeX() string {
+	if r.degree == 3 {
+		return fmt.Sprintf(`\sqrt[3]{%s}`, r.radicand.LaTeX())
+	}
+	return fmt.Sprintf(`\sqrt{%s}`, r.radicand.LaTeX())
+}
+
+func (root) precedence() int { return atomPrecedence }
+
+func (a absolute) String() string { return fmt.Sprintf("abs(%s)", a.arg) }
+func (a absolute) LaTeX() string  { return fmt.Sprintf(`\left|%s\right|`, a.arg.LaTeX()) }
+func (absolute) precedence() int  { return atomPrecedence }
+
+func (q quotient) String() string {
+	return fmt.Sprintf("%s / %s", wrap(formatNumber(q.value), constant{q.value}, productPrecedence), wrap(q.divisor.String(), q.divisor, powerPrecedence))
+}
+
+func (q quotient) LaTeX() string {
+	return fmt.Sprintf(`\frac{%s}{%s}`, latexNumber(q.value), q.divisor.LaTeX())
+}
+
+func (quotient) precedence() int { return productPrecedence }
+
+func (f function) String() string {
+	args := []string{f.arg.String()}
+	for _, p := range f.params {
+		args = append(args, formatNumber(p))
+	}
+	return fmt.Sprintf("%s(%s)", f.name, strings.Join(args, ", "))
+}
+
+func (f function) LaTeX() string {
+	arg := f.arg.LaTeX()
+	switch f.name {
+	case gcdOp:
+		return fmt.Sprintf(`\gcd\left(%s, %s\right)`, arg, latexNumber(f.params[0]))
+	case modPowOp:
+		return fmt.Sprintf(`\left(%s^{%s} \bmod %s\right)`, wrapLaTeX(arg, f.arg, atomPrecedence), latexNumber(f.params[0]), latexNumber(f.params[1]))
+	case modInvOp:
+		return fmt.Sprintf(`\left(%s^{-1} \bmod %s\right)`, wrapLaTeX(arg, f.arg, atomPrecedence), latexNumber(f.params[0]))
+	case factorialOp:
+		return wrapLaTeX(arg, f.arg, atomPrecedence) + "!"
+	case gammaOp:
+		return fmt.Sprintf(`\Gamma\left(%s\right)`, arg)
+	case logGammaOp:
+		return fmt.Sprintf(`\ln\left|\Gamma\left(%s\right)\right|`, arg)
+	case chooseOp:
+		return fmt.Sprintf(`\binom{%s}{%s}`, arg, latexNumber(f.params[0]))
+	case binomialOp:
+		return fmt.Sprintf(`\binom{%s}{%s} %s^{%s} \left(1 - %s\right)^{%s - %s}`, arg, latexNumber(f.params[0]),
+			latexNumber(f.params[1]), latexNumber(f.params[0]), latexNumber(f.params[1]), arg, latexNumber(f.params[0]))
+	}
+
+	args := []string{arg}
+	for _, p := range f.params {
+		args = append(args, latexNumber(p))
+	}
+	return fmt.Sprintf(`\operatorname{%s}\left(%s\right)`, f.name, strings.Join(args, ", "))
+}
+
+// the latex of modpow and modinv is parenthesized itself as "a^e mod m" binds looser than a product
+func (function) precedence() int { return atomPrecedence }
+
+// wrap puts parentheses around s when the expression binds looser than the required precedence
+func wrap(s string, e Expression, required int) string {
+	if e.precedence() < required {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+func wrapLaTeX(s string, e Expression, required int) string {
+	if e.precedence() < required {
+		return `\left(` + s + `\right)`
+	}
+	return s
+}
+
+// noiseUlps is how many ulps a constant may be off its shortest form, folding factors and offsets
+// together leaves that much float noise, e.g. 1.1 * 1.1 is 1.2100000000000002
+const noiseUlps = 64
+
+// formatNumber prints f with the fewest digits within noiseUlps of it
+func formatNumber(f float64) string {
+	if f == 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+
+	ulp := math.Nextafter(math.Abs(f), math.Inf(1)) - math.Abs(f)
+	for precision := 1; precision < 17; precision++ {
+		// reparse the rounded digits so that the notation stays the one of -1, 80 rather than 8e+01
+		g, err := strconv.ParseFloat(strconv.FormatFloat(f, 'g', precision, 64), 64)
+		if err == nil && math.Abs(g-f) <= noiseUlps*ulp {
+			return strconv.FormatFloat(g, 'g', -1, 64)
+		}
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func latexNumber(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return `\mathrm{NaN}`
+	case math.IsInf(f, 1):
+		return `\infty`
+	case math.IsInf(f, -1):
+		return `-\infty`
+	}
+
+	s := formatNumber(f)
+	mantissa, exponent, found := strings.Cut(s, "e")
+	if !found {
+		return s
+	}
+	exp, _ := strconv.Atoi(exponent)
+	return fmt.Sprintf(`%s \times 10^{%d}`, mantissa, exp)
+}
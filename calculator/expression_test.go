@@ -0,0 +1,154 @@
+package calculator
+
+import (
+	"testing"
+)
+
+func TestNewCalculator_GetFormula(t *testing.T) {
+	tests := []struct {
+		name      string
+		ops       func(c *newCalculator)
+		want      string
+		wantLaTeX string
+	}{
+		{
+			name:      "no history - return the starting value",
+			ops:       func(c *newCalculator) {},
+			want:      "x",
+			wantLaTeX: "x",
+		},
+		{
+			name: "consecutive additions are folded",
+			ops: func(c *newCalculator) {
+				c.Add(2).Add(3).Subtract(1)
+			},
+			want:      "x + 4",
+			wantLaTeX: "x + 4",
+		},
+		{
+			name: "additions cancelling each other - return the starting value",
+			ops: func(c *newCalculator) {
+				c.Add(2).Subtract(2)
+			},
+			want:      "x",
+			wantLaTeX: "x",
+		},
+		{
+			name: "number theory functions",
+			ops: func(c *newCalculator) {
+				c.GCD(12).ModPow(3, 7).Multiply(2)
+			},
+			want:      "modpow(gcd(x, 12), 3, 7) * 2",
+			wantLaTeX: `\left(\gcd\left(x, 12\right)^{3} \bmod 7\right) \cdot 2`,
+		},
+		{
+			name: "factorial and combinations",
+			ops: func(c *newCalculator) {
+				c.Add(1).Factorial().Choose(2)
+			},
+			want:      "ncr(fact(x + 1), 2)",
+			wantLaTeX: `\binom{\left(x + 1\right)!}{2}`,
+		},
+		{
+			name: "number theory function of a constant is folded",
+			ops: func(c *newCalculator) {
+				c.Multiply(0).Add(10).NextPrime()
+			},
+			want:      "11",
+			wantLaTeX: "11",
+		},
+		{
+			name: "nested operations keep their precedence",
+			ops: func(c *newCalculator) {
+				c.Add(5).Multiply(3).Root(2).Pow(2)
+			},
+			want:      "sqrt((x + 5) * 3)^2",
+			wantLaTeX: `\sqrt{\left(x + 5\right) \cdot 3}^{2}`,
+		},
+		{
+			name: "division is drawn as a fraction in latex",
+			ops: func(c *newCalculator) {
+				c.Subtract(1).Divide(2).Divide(2)
+			},
+			want:      "(x - 1) / 4",
+			wantLaTeX: `\frac{x - 1}{4}`,
+		},
+		{
+			name: "cube root of a cube is simplified",
+			ops: func(c *newCalculator) {
+				c.Pow(3).Root(3)
+			},
+			want:      "x",
+			wantLaTeX: "x",
+		},
+		{
+			name: "square root of a square is simplified to absolute",
+			ops: func(c *newCalculator) {
+				c.Add(1).Pow(2).Root(2)
+			},
+			want:      "abs(x + 1)",
+			wantLaTeX: `\left|x + 1\right|`,
+		},
+		{
+			name: "multiply by zero - return constant",
+			ops: func(c *newCalculator) {
+				c.Add(1).Multiply(0).Add(3)
+			},
+			want:      "3",
+			wantLaTeX: "3",
+		},
+		{
+			name: "negative factor is parenthesized",
+			ops: func(c *newCalculator) {
+				c.Multiply(-1).Pow(-1)
+			},
+			want:      "(x * (-1))^(-1)",
+			wantLaTeX: `\left(x \cdot \left(-1\right)\right)^{-1}`,
+		},
+		{
+			name: "cancel resets the formula",
+			ops: func(c *newCalculator) {
+				c.Add(1).Cancel().Multiply(2)
+			},
+			want:      "x * 2",
+			wantLaTeX: `x \cdot 2`,
+		},
+		{
+			name: "repeat extends the formula",
+			ops: func(c *newCalculator) {
+				c.Add(1).Multiply(2).Repeat(1)
+			},
+			want:      "(x + 1) * 4",
+			wantLaTeX: `\left(x + 1\right) \cdot 4`,
+		},
+		{
+			name: "folded factors hide the float noise",
+			ops: func(c *newCalculator) {
+				c.Add(100).AddPercent(10).Repeat(1)
+			},
+			want:      "(x + 100) * 1.21",
+			wantLaTeX: `\left(x + 100\right) \cdot 1.21`,
+		},
+		{
+			name: "large numbers use scientific notation in latex",
+			ops: func(c *newCalculator) {
+				c.Add(1e21)
+			},
+			want:      "x + 1e+21",
+			wantLaTeX: `x + 1 \times 10^{21}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := InitNewCalculator()
+			tt.ops(c)
+			got := c.GetFormula()
+			if got.String() != tt.want {
+				t.Errorf("Calculator.GetFormula().String() = %v, want %v", got.String(), tt.want)
+			}
+			if got.LaTeX() != tt.wantLaTeX {
+				t.Errorf("Calculator.GetFormula().LaTeX() = %v, want %v", got.LaTeX(), tt.wantLaTeX)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package calculator
+
+// significant figures of current. an operand may be measured, e.g. 2.50 known to the hundredth, and every
+// operation computes the precision of current with the rules of significant figures. operations without
+// rule of their own, like abs or gamma, keep the significant digits of current.
+
+import (
+	"gitlab.com/atthoriq/calculator-project/sigfig"
+)
+
+// GetFigures returns the precision of current, exact when no operand was measured
+func (c *newCalculator) GetFigures() sigfig.Figures {
+	// clean hold operations
+	c.GetResult()
+
+	return c.figures
+}
+
+// Significant tells the operand of the last queued operation is measured with the precision f, e.g. Add(2.5).Significant(sigfig.At(-2)).
+// it does nothing when no operation is queued
+func (c *newCalculator) Significant(f sigfig.Figures) NewCalculator {
+	if len(c.currentOperations) > 0 {
+		c.currentOperations[len(c.currentOperations)-1].figures = &f
+	}
+	return c
+}
+
+// operandFigures is the precision of the first argument of the operation
+func (o operation) operandFigures() sigfig.Figures {
+	if o.figures != nil {
+		return *o.figures
+	}
+	return sigfig.Exact()
+}
+
+// figuresAfter is the precision of current once the operation is applied on before known to f
+func (o operation) figuresAfter(f sigfig.Figures, before, current float64) sigfig.Figures {
+	x := o.operandFigures()
+	switch o.name {
+	case addOp, subtractOp:
+		return sigfig.Sum(f, x)
+	case multiplyOp, divideOp:
+		return sigfig.Product(before, f, o.args[0], x, current)
+	case convertOp:
+		// the factor of a conversion is exact, and so is the offset of a temperature
+		return sigfig.Product(before, f, o.args[0], sigfig.Exact(), current-o.args[1])
+	case exchangeOp:
+		return sigfig.Product(before, f, o.args[0], sigfig.Exact(), current)
+	default:
+		return sigfig.Keep(before, f, current)
+	}
+}
@@ -0,0 +1,64 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/atthoriq/calculator-project/sigfig"
+)
+
+func TestNewCalculator_Figures(t *testing.T) {
+	tests := []struct {
+		name      string
+		calculate func(c *newCalculator)
+		want      sigfig.Figures
+	}{
+		{
+			name:      "nothing measured",
+			calculate: func(c *newCalculator) { c.Add(2.5).Multiply(2) },
+			want:      sigfig.Exact(),
+		},
+		{
+			name:      "sum to the least precise place",
+			calculate: func(c *newCalculator) { c.Add(12.11).Significant(sigfig.At(-2)).Add(0.3).Significant(sigfig.At(-1)) },
+			want:      sigfig.At(-1),
+		},
+		{
+			name:      "product to the least significant digits",
+			calculate: func(c *newCalculator) { c.Add(2.5).Significant(sigfig.At(-2)).Multiply(1.2).Significant(sigfig.At(-1)) },
+			want:      sigfig.At(-1),
+		},
+		{
+			name:      "exact factor",
+			calculate: func(c *newCalculator) { c.Add(2.5).Significant(sigfig.At(-2)).Multiply(40) },
+			want:      sigfig.At(0),
+		},
+		{
+			name:      "root keeps the digits",
+			calculate: func(c *newCalculator) { c.Add(16).Significant(sigfig.At(-1)).Root(2) },
+			want:      sigfig.At(-2),
+		},
+		{
+			name:      "pow keeps the digits",
+			calculate: func(c *newCalculator) { c.Add(2.0).Significant(sigfig.At(-1)).Pow(10) },
+			want:      sigfig.At(2),
+		},
+		{
+			name:      "invert restores the figures",
+			calculate: func(c *newCalculator) { c.Add(2.5).Significant(sigfig.At(-2)).Multiply(2); c.Invert() },
+			want:      sigfig.Exact(),
+		},
+		{
+			name:      "cancel clears the figures",
+			calculate: func(c *newCalculator) { c.Add(2.5).Significant(sigfig.At(-2)).Cancel() },
+			want:      sigfig.Exact(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := InitNewCalculator()
+			tt.calculate(c)
+			assert.Equal(t, tt.want, c.GetFigures())
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+// inverse returns the value before the operation was applied, given the value after it.
+// it fails when the operation lost information, e.g. abs drops the sign and dividing by 0 drops everything
+func (o operation) inverse(after float64) (float64, error) {
+	if math.IsNaN(after) && (o.name != divideOp || o.args[0] != 0) {
+		return 0, fmt.Errorf("%s is not invertible: current value is NaN", o)
+	}
+
+	switch o.name {
+	case addOp:
+		return after - o.args[0], nil
+	case subtractOp:
+		return after + o.args[0], nil
+	case multiplyOp:
+		if o.args[0] == 0 {
+			return 0, fmt.Errorf("%s is not invertible: multiplying by 0 loses the value", o)
+		}
+		return after / o.args[0], nil
+	case divideOp:
+		if o.args[0] == 0 {
+			return 0, fmt.Errorf("%s is not invertible: dividing by 0 loses the value", o)
+		}
+		return after * o.args[0], nil
+	case convertOp:
+		return (after - o.args[1]) / o.args[0], nil
+	case exchangeOp:
+		return after / o.args[0], nil
+	case rootOp:
+		switch o.args[0] {
+		case 2:
+			if after < 0 {
+				return 0, fmt.Errorf("%s is not invertible: square root can't be negative", o)
+			}
+			return after * after, nil
+		case 3:
+			return after * after * after, nil
+		}
+	case powOp:
+		n := o.args[0]
+		switch {
+		case n == 0:
+			return 0, fmt.Errorf("%s is not invertible: everything to the power of 0 is 1", o)
+		case isInteger(n) && math.Mod(n, 2) == 0:
+			return 0, fmt.Errorf("%s is not invertible: even power loses the sign", o)
+		case isInteger(n) && after < 0:
+			// odd power keeps the sign
+			return -math.Pow(-after, 1/n), nil
+		default:
+			return math.Pow(after, 1/n), nil
+		}
+	case absOp:
+		return 0, fmt.Errorf("%s is not invertible: the sign is lost", o)
+	case gcdOp, lcmOp, nextPrimeOp, modPowOp, logGammaOp, chooseOp, permutationsOp, binomialOp:
+		return 0, fmt.Errorf("%s is not invertible: many values give the same result", o)
+	case factorialOp, gammaOp:
+		return 0, fmt.Errorf("%s is not invertible: the inverse of %s is not supported", o, o.name)
+	case modInvOp:
+		// the inverse of the inverse is the value itself, up to a multiple of m
+		return 0, fmt.Errorf("%s is not invertible: the value is only known modulo %s", o, formatNumber(o.args[0]))
+	case addPercentOp, markupOp:
+		if o.args[0] == -100 {
+			return 0, fmt.Errorf("%s is not invertible: the result is always 0", o)
+		}
+		return after / (1 + o.args[0]/100), nil
+	case subtractPercentOp, discountOp:
+		if o.args[0] == 100 {
+			return 0, fmt.Errorf("%s is not invertible: the result is always 0", o)
+		}
+		return after / (1 - o.args[0]/100), nil
+	case percentOfOp:
+		if o.args[0] == 0 {
+			return 0, fmt.Errorf("%s is not invertible: the result is always 0", o)
+		}
+		return after * 100 / o.args[0], nil
+	case marginOp:
+		if o.args[0] == 100 {
+			return 0, fmt.Errorf("%s is not invertible: 100%% margin has no price", o)
+		}
+		return after * (1 - o.args[0]/100), nil
+	case percentChangeOp:
+		if after == -100 {
+			return 0, fmt.Errorf("%s is not invertible: a change of -100%% comes from any value", o)
+		}
+		return 100 * o.args[0] / (after + 100), nil
+	}
+
+	return 0, fmt.Errorf("%s is not invertible", o)
+}
+
+func (o operation) String() string {
+	s := o.name
+	for _, arg := range o.args {
+		s += " " + formatNumber(arg)
+	}
+	return s
+}
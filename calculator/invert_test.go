@@ -0,0 +1,110 @@
+package calculator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCalculator_Invert(t *testing.T) {
+	tests := []struct {
+		name    string
+		ops     func(c *newCalculator)
+		want    float64
+		wantErr bool
+	}{
+		{
+			name: "no history - return current",
+			ops:  func(c *newCalculator) {},
+			want: 0,
+		},
+		{
+			name: "arithmetic operations - return starting value",
+			ops: func(c *newCalculator) {
+				c.Add(5).Multiply(3).Subtract(1).Divide(2)
+			},
+			want: 0,
+		},
+		{
+			name: "roots and odd powers - return starting value",
+			ops: func(c *newCalculator) {
+				c.Add(4).Root(2).Subtract(10).Pow(3).Root(3)
+			},
+			want: 0,
+		},
+		{
+			name: "only operations since cancel are inverted",
+			ops: func(c *newCalculator) {
+				c.Add(3).Cancel().Add(8).Multiply(2)
+			},
+			want: 0,
+		},
+		{
+			name: "abs - return error",
+			ops: func(c *newCalculator) {
+				c.Subtract(2).Abs()
+			},
+			wantErr: true,
+		},
+		{
+			name: "divide by zero - return error",
+			ops: func(c *newCalculator) {
+				c.Add(2).Divide(0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiply by zero - return error",
+			ops: func(c *newCalculator) {
+				c.Add(2).Multiply(0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "even power - return error",
+			ops: func(c *newCalculator) {
+				c.Subtract(2).Pow(2)
+			},
+			wantErr: true,
+		},
+		{
+			name: "cancel - return error",
+			ops: func(c *newCalculator) {
+				c.Add(2).Cancel()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := InitNewCalculator()
+			tt.ops(c)
+			got, err := c.Invert()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Calculator.Invert() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if math.Abs(got.GetResult()-tt.want) > 1e-9 {
+				t.Errorf("Calculator.Invert() = %v, want %v", got.GetResult(), tt.want)
+			}
+			assert.Len(t, c.history, 0)
+			assert.Equal(t, "x", c.GetFormula().String())
+		})
+	}
+}
+
+func TestNewCalculator_Invert_NaN(t *testing.T) {
+	c := InitNewCalculator()
+	c.Add(2).Divide(0).Add(1)
+	_, err := c.Invert()
+	assert.EqualError(t, err, "divide 0 is not invertible: dividing by 0 loses the value")
+
+	c = InitNewCalculator()
+	c.Subtract(4).Root(2).Multiply(3)
+	_, err = c.Invert()
+	assert.EqualError(t, err, "root 2 is not invertible: current value is NaN")
+}
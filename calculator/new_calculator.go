@@ -6,16 +6,94 @@ package calculator
 // history can be written in this package or outside of this package using similar approach.
 
 import (
+	"errors"
 	"math"
+	"time"
+
+	"gitlab.com/atthoriq/calculator-project/interval"
+	"gitlab.com/atthoriq/calculator-project/sigfig"
+	"gitlab.com/atthoriq/calculator-project/units"
 )
 
 type newCalculator struct {
 	current           float64
 	currentOperations []operation
 	history           []operation
+	expr              Expression
+	// cancelled marks the history was discarded by cancel and nothing is recorded since
+	cancelled bool
+	// now is the clock stamping applied operations
+	now       func() time.Time
+	precision Precision
+	// data and points are the dataset of the stats mode
+	data   []float64
+	points []Point
+	unit   units.Unit
+	// currency is the code current is tagged with
+	currency string
+	// bounds is the interval holding current when operands are uncertain
+	bounds interval.Interval
+	// figures is the precision of current when operands are measured
+	figures sigfig.Figures
+	// compensated carries the rounding errors of +, -, × and ÷ in compensation, roundoff estimates them all
+	compensated  bool
+	compensation float64
+	roundoff     float64
+}
+
+// operation keeps the name and arguments of a command next to the function applying it
+// so the history can be read back structurally, e.g. to render it as a formula
+type operation struct {
+	name string
+	args []float64
+	fn   func(*newCalculator)
+	// unit is the unit of the operand of add, subtract, multiply and divide, or the unit converted to
+	unit units.Unit
+	// currency is the currency exchanged to
+	currency string
+	// operand is the interval of the first argument when it is uncertain
+	operand *interval.Interval
+	// figures is the precision of the first argument when it is measured
+	figures *sigfig.Figures
+	// compensated tells the compensation moved current off the plain float64 result, filled once it is applied
+	compensated bool
+	// before, after and at are the current value around the operation and when it ran, filled once it is applied
+	before float64
+	after  float64
+	at     time.Time
+	// unitBefore, currencyBefore, boundsBefore and figuresBefore are the unit, the currency, the bounds and the precision
+	// of current before the operation, filled once it is applied
+	unitBefore     units.Unit
+	currencyBefore string
+	boundsBefore   interval.Interval
+	figuresBefore  sigfig.Figures
+}
+
+// Step is an applied operation of the history as seen from outside of the package
+type Step struct {
+	Op   string
+	Args []float64
+	// Unit is the unit of the operand, or the unit converted to
+	Unit units.Unit
+	// Currency is the currency exchanged to
+	Currency string
+	// Compensated tells After differs from the plain float64 result as accuracy on compensated it
+	Compensated bool
+	Before      float64
+	After       float64
+	At          time.Time
 }
 
-type operation func(*newCalculator)
+// operation names recorded in the history
+const (
+	OpAdd      = addOp
+	OpSubtract = subtractOp
+	OpMultiply = multiplyOp
+	OpDivide   = divideOp
+	OpAbs      = absOp
+	OpRoot     = rootOp
+	OpPow      = powOp
+)
 
 type NewCalculator interface {
 	Add(a float64) NewCalculator
@@ -25,56 +103,110 @@ type NewCalculator interface {
 	Abs() NewCalculator
 	Root(a int) NewCalculator
 	Pow(a float64) NewCalculator
+	AddPercent(p float64) NewCalculator
+	SubtractPercent(p float64) NewCalculator
+	PercentOf(p float64) NewCalculator
+	PercentChange(x float64) NewCalculator
+	Markup(p float64) NewCalculator
+	Margin(p float64) NewCalculator
+	Discount(p float64) NewCalculator
+	GCD(n float64) NewCalculator
+	LCM(n float64) NewCalculator
+	NextPrime() NewCalculator
+	ModPow(e, mod float64) NewCalculator
+	ModInv(mod float64) NewCalculator
+	Factorial() NewCalculator
+	Gamma() NewCalculator
+	LogGamma() NewCalculator
+	Choose(k float64) NewCalculator
+	Permutations(k float64) NewCalculator
+	Binomial(k, p float64) NewCalculator
 	Repeat(a int) NewCalculator
 	Cancel() NewCalculator
+	Invert() (NewCalculator, error)
 	GetResult() float64
+	GetHistory() []Step
+	GetFormula() Expression
+	SetPrecision(p Precision) error
+	GetPrecision() Precision
+	Push(x float64)
+	GetData() []float64
+	PushPoint(x, y float64)
+	GetPoints() []Point
+	ClearData()
+	SetUnit(u units.Unit)
+	GetUnit() units.Unit
+	AddUnit(a float64, u units.Unit) NewCalculator
+	SubtractUnit(a float64, u units.Unit) NewCalculator
+	MultiplyUnit(a float64, u units.Unit) NewCalculator
+	DivideUnit(a float64, u units.Unit) NewCalculator
+	Convert(a, b float64, u units.Unit) NewCalculator
+	SetCurrency(code string)
+	GetCurrency() string
+	Exchange(rate float64, code string) NewCalculator
+	GetBounds() interval.Interval
+	Within(x interval.Interval) NewCalculator
+	GetFigures() sigfig.Figures
+	Significant(f sigfig.Figures) NewCalculator
+	SetCompensated(on bool)
+	GetAccuracy() Accuracy
 }
 
 func InitNewCalculator() *newCalculator {
-	return &newCalculator{0, []operation{}, []operation{}}
+	return &newCalculator{
+		current:           0,
+		currentOperations: []operation{},
+		history:           []operation{},
+		expr:              variable{},
+		now:               time.Now,
+	}
 }
 
 func (c *newCalculator) Add(a float64) NewCalculator {
-	c.currentOperations = append(c.currentOperations, func(nc *newCalculator) {
+	a = c.round(a)
+	c.currentOperations = append(c.currentOperations, operation{name: addOp, args: []float64{a}, fn: func(nc *newCalculator) {
 		nc.current += a
-	})
+	}})
 	return c
 }
 
 func (c *newCalculator) Subtract(a float64) NewCalculator {
-	c.currentOperations = append(c.currentOperations, func(nc *newCalculator) {
+	a = c.round(a)
+	c.currentOperations = append(c.currentOperations, operation{name: subtractOp, args: []float64{a}, fn: func(nc *newCalculator) {
 		nc.current -= a
-	})
+	}})
 	return c
 }
 
 func (c *newCalculator) Multiply(a float64) NewCalculator {
-	c.currentOperations = append(c.currentOperations, func(nc *newCalculator) {
+	a = c.round(a)
+	c.currentOperations = append(c.currentOperations, operation{name: multiplyOp, args: []float64{a}, fn: func(nc *newCalculator) {
 		nc.current *= a
-	})
+	}})
 	return c
 }
 
 func (c *newCalculator) Divide(a float64) NewCalculator {
-	c.currentOperations = append(c.currentOperations, func(nc *newCalculator) {
+	a = c.round(a)
+	c.currentOperations = append(c.currentOperations, operation{name: divideOp, args: []float64{a}, fn: func(nc *newCalculator) {
 		if a == 0 {
 			nc.current = math.NaN()
 		} else {
 			nc.current /= a
 		}
-	})
+	}})
 	return c
 }
 
 func (c *newCalculator) Abs() NewCalculator {
-	c.currentOperations = append(c.currentOperations, func(nc *newCalculator) {
+	c.currentOperations = append(c.currentOperations, operation{name: absOp, args: []float64{}, fn: func(nc *newCalculator) {
 		c.current = math.Abs(c.current)
-	})
+	}})
 	return c
 }
 
 func (c *newCalculator) Root(n int) NewCalculator {
-	c.currentOperations = append(c.currentOperations, func(nc *newCalculator) {
+	c.currentOperations = append(c.currentOperations, operation{name: rootOp, args: []float64{float64(n)}, fn: func(nc *newCalculator) {
 		switch n {
 		case 2:
 			c.current = math.Sqrt(c.current)
@@ -83,21 +215,30 @@ func (c *newCalculator) Root(n int) NewCalculator {
 		default:
 			c.current = math.NaN()
 		}
-	})
+	}})
 	return c
 }
 
 func (c *newCalculator) Pow(n float64) NewCalculator {
-	c.currentOperations = append(c.currentOperations, func(nc *newCalculator) {
+	n = c.round(n)
+	c.currentOperations = append(c.currentOperations, operation{name: powOp, args: []float64{n}, fn: func(nc *newCalculator) {
 		c.current = math.Pow(c.current, n)
-	})
+	}})
 	return c
 }
 
 func (c *newCalculator) Cancel() NewCalculator {
+	c.cancelled = c.cancelled || len(c.history) > 0 || len(c.currentOperations) > 0
 	c.current = 0
 	c.currentOperations = []operation{}
 	c.history = []operation{}
+	c.expr = variable{}
+	c.unit = units.None
+	c.currency = ""
+	c.bounds = interval.Point(0)
+	c.figures = sigfig.Exact()
+	c.compensation = 0
+	c.roundoff = 0
 	return c
 }
 
@@ -116,17 +257,110 @@ func (c *newCalculator) Repeat(n int) NewCalculator {
 
 	lastNhistory := c.history[startRepeat:]
 	for _, op := range lastNhistory {
-		op(c)
-		c.history = append(c.history, op)
+		// current is already in the unit or the currency a conversion converts to
+		if op.name == convertOp || op.name == exchangeOp {
+			continue
+		}
+		c.apply(op)
 	}
 	return c
 }
 
+// Invert undoes the whole recorded history to recover the starting value from the current one.
+// the history is cleared afterwards as the calculation is back at its start
+func (c *newCalculator) Invert() (NewCalculator, error) {
+	// clean hold operations
+	c.GetResult()
+
+	if len(c.history) == 0 && c.cancelled {
+		return c, errors.New("cancel is not invertible: the history before it is discarded")
+	}
+
+	// NaN goes through every later step, the step to blame is the one which made it
+	for _, op := range c.history {
+		if math.IsNaN(op.after) {
+			_, err := op.inverse(op.after)
+			return c, err
+		}
+	}
+
+	value := c.current
+	for i := len(c.history) - 1; i >= 0; i-- {
+		v, err := c.history[i].inverse(value)
+		if err != nil {
+			return c, err
+		}
+		value = v
+	}
+
+	c.current = c.round(value)
+	if len(c.history) > 0 {
+		c.unit = c.history[0].unitBefore
+		c.currency = c.history[0].currencyBefore
+		c.bounds = c.history[0].boundsBefore
+		c.figures = c.history[0].figuresBefore
+	}
+	c.compensation = 0
+	c.roundoff = 0
+	c.history = []operation{}
+	c.expr = variable{}
+	return c, nil
+}
+
 func (c *newCalculator) GetResult() float64 {
 	for _, op := range c.currentOperations {
-		op(c)
-		c.history = append(c.history, op)
+		c.apply(op)
 	}
 	c.currentOperations = []operation{}
 	return c.current
 }
+
+// GetFormula returns the recorded history as an expression of the starting value
+func (c *newCalculator) GetFormula() Expression {
+	// clean hold operations
+	c.GetResult()
+
+	return c.expr
+}
+
+// GetHistory returns the applied operations since the last cancel
+func (c *newCalculator) GetHistory() []Step {
+	// clean hold operations
+	c.GetResult()
+
+	steps := make([]Step, len(c.history))
+	for i, op := range c.history {
+		steps[i] = Step{
+			Op:          op.name,
+			Args:        append([]float64{}, op.args...),
+			Unit:        op.unit,
+			Currency:    op.currency,
+			Compensated: op.compensated,
+			Before:      op.before,
+			After:       op.after,
+			At:          op.at,
+		}
+	}
+	return steps
+}
+
+// apply runs the operation and records it both in the history and in the formula
+func (c *newCalculator) apply(op operation) {
+	op.before = c.current
+	op.unitBefore = c.unit
+	op.currencyBefore = c.currency
+	op.boundsBefore = c.bounds
+	op.figuresBefore = c.figures
+	op.fn(c)
+	c.unit = op.unitAfter(c.unit)
+	c.currency = op.currencyAfter(c.currency)
+	c.current = c.round(c.current)
+	op.compensated = c.compensate(op, op.before)
+	c.bounds = op.boundsAfter(c.bounds, c.current)
+	c.figures = op.figuresAfter(c.figures, op.before, c.current)
+	op.after = c.current
+	op.at = c.now()
+	c.history = append(c.history, op)
+	c.expr = op.expression(c.expr)
+	c.cancelled = false
+}
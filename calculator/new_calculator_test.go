@@ -563,3 +563,94 @@ func TestNewCalculator_Repeat_With_Non_Math_Op(t *testing.T) {
 		})
 	}
 }
+
+func TestNewCalculator_Precision(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision Precision
+		ops       func(c *newCalculator)
+		want      float64
+		wantErr   bool
+	}{
+		{
+			name:      "float64 keeps the float64 result",
+			precision: Float64,
+			ops: func(c *newCalculator) {
+				c.Add(0.1).Add(0.2)
+			},
+			want: 0.30000000000000004,
+		},
+		{
+			name:      "float32 rounds operands and results",
+			precision: Float32,
+			ops: func(c *newCalculator) {
+				c.Add(0.1).Add(0.2)
+			},
+			want: float64(float32(0.1) + float32(0.2)),
+		},
+		{
+			name:      "float32 overflows to infinity",
+			precision: Float32,
+			ops: func(c *newCalculator) {
+				c.Add(math.MaxFloat32).Multiply(2)
+			},
+			want: math.Inf(1),
+		},
+		{
+			name:      "float32 rounds the root",
+			precision: Float32,
+			ops: func(c *newCalculator) {
+				c.Add(2).Root(2)
+			},
+			want: float64(float32(math.Sqrt(2))),
+		},
+		{
+			name:      "unknown precision",
+			precision: "float16",
+			ops:       func(c *newCalculator) {},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := InitNewCalculator()
+			err := c.SetPrecision(tt.precision)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Calculator.SetPrecision() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Equal(t, Float64, c.GetPrecision())
+				return
+			}
+			tt.ops(c)
+			if got := c.GetResult(); !floatEqual(got, tt.want) {
+				t.Errorf("Calculator.GetResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCalculator_Data(t *testing.T) {
+	c := InitNewCalculator()
+	c.Add(5).GetResult()
+	c.Push(1)
+	c.Push(2.5)
+
+	data := c.GetData()
+	assert.Equal(t, []float64{1, 2.5}, data)
+	data[0] = 10
+	assert.Equal(t, []float64{1, 2.5}, c.GetData(), "GetData returns a copy")
+	assert.Equal(t, float64(5), c.GetResult(), "the dataset doesn't touch current")
+
+	assert.NoError(t, c.SetPrecision(Float32))
+	c.Push(0.1)
+	assert.Equal(t, float64(float32(0.1)), c.GetData()[2])
+
+	c.PushPoint(1, 0.1)
+	assert.Equal(t, []Point{{1, float64(float32(0.1))}}, c.GetPoints())
+
+	c.ClearData()
+	assert.Empty(t, c.GetData())
+	assert.Empty(t, c.GetPoints())
+}
@@ -0,0 +1,107 @@
+package calculator
+
+// number theory operations on integer current. a current or an argument which isn't an exact integer makes the result NaN,
+// the same way dividing by 0 does.
+
+import (
+	"math"
+
+	"gitlab.com/atthoriq/calculator-project/numtheory"
+)
+
+const (
+	gcdOp       = "gcd"
+	lcmOp       = "lcm"
+	nextPrimeOp = "nextprime"
+	modPowOp    = "modpow"
+	modInvOp    = "modinv"
+
+	OpGCD       = gcdOp
+	OpLCM       = lcmOp
+	OpNextPrime = nextPrimeOp
+	OpModPow    = modPowOp
+	OpModInv    = modInvOp
+)
+
+// GCD is the greatest common divisor of current and n
+func (c *newCalculator) GCD(n float64) NewCalculator {
+	c.currentOperations = append(c.currentOperations, operation{name: gcdOp, args: []float64{n}, fn: func(nc *newCalculator) {
+		nc.integer(func(x int64) (int64, error) {
+			b, err := numtheory.FromFloat(n)
+			return numtheory.GCD(x, b), err
+		})
+	}})
+	return c
+}
+
+// LCM is the least common multiple of current and n
+func (c *newCalculator) LCM(n float64) NewCalculator {
+	c.currentOperations = append(c.currentOperations, operation{name: lcmOp, args: []float64{n}, fn: func(nc *newCalculator) {
+		nc.integer(func(x int64) (int64, error) {
+			b, err := numtheory.FromFloat(n)
+			if err != nil {
+				return 0, err
+			}
+			return numtheory.LCM(x, b)
+		})
+	}})
+	return c
+}
+
+// NextPrime is the smallest prime greater than current
+func (c *newCalculator) NextPrime() NewCalculator {
+	c.currentOperations = append(c.currentOperations, operation{name: nextPrimeOp, fn: func(nc *newCalculator) {
+		nc.integer(func(x int64) (int64, error) {
+			return numtheory.NextPrime(x), nil
+		})
+	}})
+	return c
+}
+
+// ModPow is current to the power of e modulo mod
+func (c *newCalculator) ModPow(e, mod float64) NewCalculator {
+	c.currentOperations = append(c.currentOperations, operation{name: modPowOp, args: []float64{e, mod}, fn: func(nc *newCalculator) {
+		nc.integer(func(x int64) (int64, error) {
+			exp, err := numtheory.FromFloat(e)
+			if err != nil {
+				return 0, err
+			}
+			m, err := numtheory.FromFloat(mod)
+			if err != nil {
+				return 0, err
+			}
+			return numtheory.ModPow(x, exp, m)
+		})
+	}})
+	return c
+}
+
+// ModInv is the inverse of current modulo mod
+func (c *newCalculator) ModInv(mod float64) NewCalculator {
+	c.currentOperations = append(c.currentOperations, operation{name: modInvOp, args: []float64{mod}, fn: func(nc *newCalculator) {
+		nc.integer(func(x int64) (int64, error) {
+			m, err := numtheory.FromFloat(mod)
+			if err != nil {
+				return 0, err
+			}
+			return numtheory.ModInv(x, m)
+		})
+	}})
+	return c
+}
+
+// integer applies fn on current read as integer, current becomes NaN when it isn't an integer or fn fails
+func (c *newCalculator) integer(fn func(x int64) (int64, error)) {
+	x, err := numtheory.FromFloat(c.current)
+	if err != nil {
+		c.current = math.NaN()
+		return
+	}
+
+	res, err := fn(x)
+	if err != nil {
+		c.current = math.NaN()
+		return
+	}
+	c.current = float64(res)
+}
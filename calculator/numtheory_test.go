@@ -0,0 +1,78 @@
+package calculator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCalculator_NumberTheory(t *testing.T) {
+	tests := []struct {
+		name      string
+		calculate func(c NewCalculator) NewCalculator
+		want      float64
+	}{
+		{
+			name:      "gcd",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(12).GCD(18) },
+			want:      6,
+		},
+		{
+			name:      "lcm",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(4).LCM(6) },
+			want:      12,
+		},
+		{
+			name:      "next prime",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(100).NextPrime() },
+			want:      101,
+		},
+		{
+			name:      "modpow",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(4).ModPow(13, 497) },
+			want:      445,
+		},
+		{
+			name:      "modinv",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(3).ModInv(11) },
+			want:      4,
+		},
+		{
+			name:      "modinv without inverse is NaN",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(6).ModInv(9) },
+			want:      math.NaN(),
+		},
+		{
+			name:      "non integer current is NaN",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(1.5).GCD(3) },
+			want:      math.NaN(),
+		},
+		{
+			name:      "non integer argument is NaN",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(4).LCM(2.5) },
+			want:      math.NaN(),
+		},
+		{
+			name:      "repeat modpow",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(2).ModPow(2, 1000).Repeat(1) },
+			want:      16,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.calculate(InitNewCalculator()).GetResult(); !floatEqual(got, tt.want) {
+				t.Errorf("Calculator.GetResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCalculator_NumberTheory_Invert(t *testing.T) {
+	c := InitNewCalculator()
+	c.Add(12).GCD(18).GetResult()
+
+	_, err := c.Invert()
+	assert.EqualError(t, err, "gcd 18 is not invertible: many values give the same result")
+	assert.Equal(t, []float64{13, 7}, InitNewCalculator().ModPow(13, 7).GetHistory()[0].Args)
+}
@@ -0,0 +1,94 @@
+package calculator
+
+// percentage operations of a desk calculator. p is always given in percent, e.g. 15 for 15%
+
+import "math"
+
+const (
+	addPercentOp      = "addpercent"
+	subtractPercentOp = "subtractpercent"
+	percentOfOp       = "percentof"
+	percentChangeOp   = "pctchange"
+	markupOp          = "markup"
+	marginOp          = "margin"
+	discountOp        = "discount"
+
+	OpAddPercent      = addPercentOp
+	OpSubtractPercent = subtractPercentOp
+	OpPercentOf       = percentOfOp
+	OpPercentChange   = percentChangeOp
+	OpMarkup          = markupOp
+	OpMargin          = marginOp
+	OpDiscount        = discountOp
+)
+
+// AddPercent adds p percent of current to current, e.g. 200 + 15% is 230
+func (c *newCalculator) AddPercent(p float64) NewCalculator {
+	p = c.round(p)
+	c.currentOperations = append(c.currentOperations, operation{name: addPercentOp, args: []float64{p}, fn: func(nc *newCalculator) {
+		nc.current += nc.current * p / 100
+	}})
+	return c
+}
+
+// SubtractPercent subtracts p percent of current from current, e.g. 200 - 15% is 170
+func (c *newCalculator) SubtractPercent(p float64) NewCalculator {
+	p = c.round(p)
+	c.currentOperations = append(c.currentOperations, operation{name: subtractPercentOp, args: []float64{p}, fn: func(nc *newCalculator) {
+		nc.current -= nc.current * p / 100
+	}})
+	return c
+}
+
+// PercentOf takes p percent of current, e.g. 15% of 200 is 30
+func (c *newCalculator) PercentOf(p float64) NewCalculator {
+	p = c.round(p)
+	c.currentOperations = append(c.currentOperations, operation{name: percentOfOp, args: []float64{p}, fn: func(nc *newCalculator) {
+		nc.current = nc.current * p / 100
+	}})
+	return c
+}
+
+// PercentChange is the change from current to x in percent of current, e.g. from 200 to 230 is 15
+func (c *newCalculator) PercentChange(x float64) NewCalculator {
+	x = c.round(x)
+	c.currentOperations = append(c.currentOperations, operation{name: percentChangeOp, args: []float64{x}, fn: func(nc *newCalculator) {
+		if nc.current == 0 {
+			nc.current = math.NaN()
+		} else {
+			nc.current = (x - nc.current) / nc.current * 100
+		}
+	}})
+	return c
+}
+
+// Markup is the price of current cost marked up by p percent of the cost
+func (c *newCalculator) Markup(p float64) NewCalculator {
+	p = c.round(p)
+	c.currentOperations = append(c.currentOperations, operation{name: markupOp, args: []float64{p}, fn: func(nc *newCalculator) {
+		nc.current += nc.current * p / 100
+	}})
+	return c
+}
+
+// Margin is the price of current cost when p percent of the price is margin, e.g. 75 with 25% margin is 100
+func (c *newCalculator) Margin(p float64) NewCalculator {
+	p = c.round(p)
+	c.currentOperations = append(c.currentOperations, operation{name: marginOp, args: []float64{p}, fn: func(nc *newCalculator) {
+		if p == 100 {
+			nc.current = math.NaN()
+		} else {
+			nc.current = nc.current / (1 - p/100)
+		}
+	}})
+	return c
+}
+
+// Discount is current price reduced by p percent
+func (c *newCalculator) Discount(p float64) NewCalculator {
+	p = c.round(p)
+	c.currentOperations = append(c.currentOperations, operation{name: discountOp, args: []float64{p}, fn: func(nc *newCalculator) {
+		nc.current -= nc.current * p / 100
+	}})
+	return c
+}
@@ -0,0 +1,91 @@
+package calculator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCalculator_Percent(t *testing.T) {
+	tests := []struct {
+		name      string
+		calculate func(c NewCalculator) NewCalculator
+		want      float64
+	}{
+		{
+			name:      "add percent",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(200).AddPercent(15) },
+			want:      230,
+		},
+		{
+			name:      "subtract percent",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(200).SubtractPercent(15) },
+			want:      170,
+		},
+		{
+			name:      "percent of",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(200).PercentOf(15) },
+			want:      30,
+		},
+		{
+			name:      "percent change",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(200).PercentChange(230) },
+			want:      15,
+		},
+		{
+			name:      "percent change from 0 is NaN",
+			calculate: func(c NewCalculator) NewCalculator { return c.PercentChange(230) },
+			want:      math.NaN(),
+		},
+		{
+			name:      "markup",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(80).Markup(25) },
+			want:      100,
+		},
+		{
+			name:      "margin",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(75).Margin(25) },
+			want:      100,
+		},
+		{
+			name:      "margin of 100 percent is NaN",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(75).Margin(100) },
+			want:      math.NaN(),
+		},
+		{
+			name:      "discount",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(80).Discount(25) },
+			want:      60,
+		},
+		{
+			name:      "repeat percentages",
+			calculate: func(c NewCalculator) NewCalculator { return c.Add(100).AddPercent(10).Repeat(1) },
+			want:      121,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.calculate(InitNewCalculator()).GetResult(); !floatEqual(got, tt.want) {
+				t.Errorf("Calculator.GetResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCalculator_Percent_History(t *testing.T) {
+	c := InitNewCalculator()
+	c.Add(80).Markup(25).Discount(10).GetResult()
+
+	assert.Equal(t, float64(90), c.GetResult())
+	history := c.GetHistory()
+	assert.Len(t, history, 3)
+	assert.Equal(t, OpMarkup, history[1].Op)
+	assert.Equal(t, []float64{25}, history[1].Args)
+	assert.Equal(t, OpDiscount, history[2].Op)
+	assert.Equal(t, "(x + 80) * 1.125", c.GetFormula().String())
+
+	inverted, err := c.Invert()
+	assert.NoError(t, err)
+	assert.InDelta(t, 0, inverted.GetResult(), 1e-9)
+}
@@ -0,0 +1,43 @@
+package calculator
+
+import "fmt"
+
+// Precision is the floating point type the calculator computes with.
+// with Float32 every operand and every result is rounded to float32, reproducing what float32 clients compute
+type Precision string
+
+const (
+	Float64 Precision = "float64"
+	Float32 Precision = "float32"
+)
+
+// SetPrecision changes the precision, current is rounded right away when it gets lower
+func (c *newCalculator) SetPrecision(p Precision) error {
+	if p != Float64 && p != Float32 {
+		return fmt.Errorf("invalid precision %q: use float32 or float64", p)
+	}
+
+	// clean hold operations
+	c.GetResult()
+
+	c.precision = p
+	c.current = c.round(c.current)
+	c.compensation = 0
+	return nil
+}
+
+func (c *newCalculator) GetPrecision() Precision {
+	if c.precision == "" {
+		return Float64
+	}
+	return c.precision
+}
+
+// round rounds f to the precision of the calculator. for +, -, *, / and sqrt rounding the float64 result
+// is the same as computing in float32 because float64 has more than twice the bits of float32
+func (c *newCalculator) round(f float64) float64 {
+	if c.precision == Float32 {
+		return float64(float32(f))
+	}
+	return f
+}
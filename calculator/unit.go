@@ -0,0 +1,98 @@
+package calculator
+
+// unit of current. adding or subtracting a quantity gives its unit to a current without one,
+// multiplying or dividing by a quantity multiplies or divides the unit of current,
+// roots and powers apply on it, and the other operations keep it. as the unit change is part of the
+// operation, repeat and invert get the unit right too. convert is an operation of its own which repeat skips,
+// current being already in the unit converted to.
+
+import "gitlab.com/atthoriq/calculator-project/units"
+
+const (
+	convertOp = "convert"
+
+	OpConvert = convertOp
+)
+
+// SetUnit tags current with u without changing its value
+func (c *newCalculator) SetUnit(u units.Unit) {
+	// clean hold operations
+	c.GetResult()
+
+	c.unit = u
+}
+
+func (c *newCalculator) GetUnit() units.Unit {
+	// clean hold operations
+	c.GetResult()
+
+	return c.unit
+}
+
+// AddUnit adds the quantity a of unit u, a current without unit takes u
+func (c *newCalculator) AddUnit(a float64, u units.Unit) NewCalculator {
+	c.Add(a)
+	c.currentOperations[len(c.currentOperations)-1].unit = u
+	return c
+}
+
+// SubtractUnit subtracts the quantity a of unit u, a current without unit takes u
+func (c *newCalculator) SubtractUnit(a float64, u units.Unit) NewCalculator {
+	c.Subtract(a)
+	c.currentOperations[len(c.currentOperations)-1].unit = u
+	return c
+}
+
+// MultiplyUnit multiplies current by the quantity a of unit u
+func (c *newCalculator) MultiplyUnit(a float64, u units.Unit) NewCalculator {
+	c.Multiply(a)
+	c.currentOperations[len(c.currentOperations)-1].unit = u
+	return c
+}
+
+// DivideUnit divides current by the quantity a of unit u
+func (c *newCalculator) DivideUnit(a float64, u units.Unit) NewCalculator {
+	c.Divide(a)
+	c.currentOperations[len(c.currentOperations)-1].unit = u
+	return c
+}
+
+// Convert converts current to the unit u, current becomes a*current + b
+func (c *newCalculator) Convert(a, b float64, u units.Unit) NewCalculator {
+	c.currentOperations = append(c.currentOperations, operation{name: convertOp, args: []float64{a, b}, unit: u, fn: func(nc *newCalculator) {
+		nc.current = nc.current*a + b
+	}})
+	return c
+}
+
+// unitAfter is the unit of current once the operation is applied on a current of unit u
+func (o operation) unitAfter(u units.Unit) units.Unit {
+	switch o.name {
+	case addOp, subtractOp:
+		if u.IsNone() {
+			return o.unit
+		}
+		return u
+	case multiplyOp:
+		return u.Mul(o.unit)
+	case divideOp:
+		return u.Div(o.unit)
+	case rootOp:
+		root, err := u.Root(int(o.args[0]))
+		if err != nil {
+			return units.None
+		}
+		return root
+	case powOp:
+		if !isInteger(o.args[0]) {
+			return units.None
+		}
+		return u.Pow(int(o.args[0]))
+	case convertOp:
+		return o.unit
+	case percentChangeOp:
+		return units.None
+	default:
+		return u
+	}
+}
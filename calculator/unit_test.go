@@ -0,0 +1,127 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/atthoriq/calculator-project/units"
+)
+
+func TestNewCalculator_Unit(t *testing.T) {
+	km, _ := units.Parse("km")
+	h, _ := units.Parse("h")
+	m, _ := units.Parse("m")
+
+	tests := []struct {
+		name      string
+		calculate func(c *newCalculator)
+		want      string
+	}{
+		{
+			name:      "no unit",
+			calculate: func(c *newCalculator) { c.Add(5).Multiply(2) },
+			want:      "",
+		},
+		{
+			name: "add keeps the unit",
+			calculate: func(c *newCalculator) {
+				c.SetUnit(km)
+				c.Add(5).Add(3).Abs()
+			},
+			want: "km",
+		},
+		{
+			name:      "add tags current without unit",
+			calculate: func(c *newCalculator) { c.AddUnit(5, km).Add(3).SubtractUnit(1, km) },
+			want:      "km",
+		},
+		{
+			name: "invert drops the unit taken by add",
+			calculate: func(c *newCalculator) {
+				c.AddUnit(5, km).MultiplyUnit(2, h)
+				c.Invert()
+			},
+			want: "",
+		},
+		{
+			name: "multiply and divide",
+			calculate: func(c *newCalculator) {
+				c.SetUnit(km)
+				c.Add(10).DivideUnit(2, h).MultiplyUnit(3, m)
+			},
+			want: "km·m/h",
+		},
+		{
+			name: "units cancel out",
+			calculate: func(c *newCalculator) {
+				c.SetUnit(km)
+				c.Add(10).DivideUnit(2, km)
+			},
+			want: "",
+		},
+		{
+			name: "root and integer power",
+			calculate: func(c *newCalculator) {
+				c.SetUnit(m)
+				c.Add(2).Pow(3).MultiplyUnit(1, m).Root(2)
+			},
+			want: "m²",
+		},
+		{
+			name: "fractional power drops the unit",
+			calculate: func(c *newCalculator) {
+				c.SetUnit(m)
+				c.Add(2).Pow(0.5)
+			},
+			want: "",
+		},
+		{
+			name: "repeat applies the unit again",
+			calculate: func(c *newCalculator) {
+				c.SetUnit(m)
+				c.Add(2).MultiplyUnit(3, m).Repeat(1)
+			},
+			want: "m³",
+		},
+		{
+			name: "invert restores the unit",
+			calculate: func(c *newCalculator) {
+				c.SetUnit(km)
+				c.Add(5).MultiplyUnit(2, h).DivideUnit(4, m)
+				c.Invert()
+			},
+			want: "km",
+		},
+		{
+			name: "convert sets the unit",
+			calculate: func(c *newCalculator) {
+				c.SetUnit(km)
+				c.Add(5).Convert(1000, 0, m)
+			},
+			want: "m",
+		},
+		{
+			name: "repeat skips convert",
+			calculate: func(c *newCalculator) {
+				c.SetUnit(km)
+				c.Add(5).Convert(1000, 0, m).Repeat(2)
+			},
+			want: "m",
+		},
+		{
+			name: "cancel clears the unit",
+			calculate: func(c *newCalculator) {
+				c.SetUnit(km)
+				c.Add(5).Cancel()
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := InitNewCalculator()
+			tt.calculate(c)
+			assert.Equal(t, tt.want, c.GetUnit().String())
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package chrono
+
+// calendar arithmetic: differences in years, months and days, and business days. a business day is
+// a weekday which is not a holiday, the holidays are read from a file as there's no universal list of them.
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Holidays maps the dates of the holidays, written as 2006-01-02, to their name
+type Holidays map[string]string
+
+// ReadHolidays reads a date per line, optionally followed by the name of the holiday, e.g. 2026-12-25 Christmas.
+// blank lines and lines starting with # are skipped
+func ReadHolidays(r io.Reader) (Holidays, error) {
+	holidays := Holidays{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		date, name, _ := strings.Cut(text, " ")
+		if _, err := time.Parse(dateLayout, date); err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q, expected YYYY-MM-DD", line, date)
+		}
+		holidays[date] = strings.TrimSpace(name)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return holidays, nil
+}
+
+// IsWorkday tells whether the date of t is neither a weekend nor a holiday
+func (h Holidays) IsWorkday(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	_, ok := h[t.Format(dateLayout)]
+	return !ok
+}
+
+// Workdays counts the business days from the date of from to the date of to, both included.
+// the count is negative when to is before from
+func Workdays(from, to time.Time, h Holidays) int {
+	sign := 1
+	if to.Before(from) {
+		from, to, sign = to, from, -1
+	}
+
+	start := date(from)
+	end := date(to.In(from.Location()))
+	count := 0
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if h.IsWorkday(d) {
+			count++
+		}
+	}
+	return sign * count
+}
+
+// AddWorkdays moves t by n business days, skipping weekends and holidays. the time of the day is kept
+func AddWorkdays(t time.Time, n int, h Holidays) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if h.IsWorkday(t) {
+			n--
+		}
+	}
+	return t
+}
+
+// date is the midnight starting the day of t
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// Period is a difference between two instants on the calendar
+type Period struct {
+	Negative                bool
+	Years, Months, Days     int
+	Hours, Minutes, Seconds int
+	Nanoseconds             int
+}
+
+// Diff is the period from from to to, counted in the time zone of from. a month from january 31 ends
+// on the last day of february
+func Diff(from, to time.Time) Period {
+	to = to.In(from.Location())
+
+	var p Period
+	if to.Before(from) {
+		from, to, p.Negative = to, from, true
+	}
+
+	// whole months first, then whole days, then what is left of the last day
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	start := addMonths(from, months)
+	if start.After(to) {
+		months--
+		start = addMonths(from, months)
+	}
+	p.Years, p.Months = months/12, months%12
+
+	for start.AddDate(0, 0, p.Days+1).Compare(to) <= 0 {
+		p.Days++
+	}
+	rest := to.Sub(start.AddDate(0, 0, p.Days))
+
+	p.Hours = int(rest / time.Hour)
+	p.Minutes = int(rest % time.Hour / time.Minute)
+	p.Seconds = int(rest % time.Minute / time.Second)
+	p.Nanoseconds = int(rest % time.Second)
+	return p
+}
+
+// addMonths moves t by n months, keeping the day of the month unless the month is shorter
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	last := time.Date(first.Year(), first.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
+
+// String writes the period as e.g. 1y 2mo 3d 4h 5m 6s, leaving the zero parts out
+func (p Period) String() string {
+	parts := []string{}
+	for _, part := range []struct {
+		n      int
+		symbol string
+	}{{p.Years, "y"}, {p.Months, "mo"}, {p.Days, "d"}, {p.Hours, "h"}, {p.Minutes, "m"}} {
+		if part.n != 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", part.n, part.symbol))
+		}
+	}
+	if p.Seconds != 0 || p.Nanoseconds != 0 {
+		parts = append(parts, FormatDuration(time.Duration(p.Seconds)*time.Second+time.Duration(p.Nanoseconds)))
+	}
+	if len(parts) == 0 {
+		return "0s"
+	}
+
+	sign := ""
+	if p.Negative {
+		sign = "-"
+	}
+	return sign + strings.Join(parts, " ")
+}
@@ -0,0 +1,113 @@
+package chrono
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func day(s string) time.Time {
+	t, _ := time.Parse(dateLayout, s)
+	return t
+}
+
+func TestReadHolidays(t *testing.T) {
+	h, err := ReadHolidays(strings.NewReader("# 2026\n2026-12-25 Christmas Day\n\n2026-12-26\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, Holidays{"2026-12-25": "Christmas Day", "2026-12-26": ""}, h)
+
+	_, err = ReadHolidays(strings.NewReader("2026-12-25\n25/12/2026\n"))
+	assert.EqualError(t, err, `line 2: invalid date "25/12/2026", expected YYYY-MM-DD`)
+}
+
+func TestWorkdays(t *testing.T) {
+	holidays := Holidays{"2026-12-25": "Christmas Day"}
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     int
+	}{
+		{name: "a week", from: "2026-10-19", to: "2026-10-23", want: 5},
+		{name: "over a weekend", from: "2026-10-23", to: "2026-10-26", want: 2},
+		{name: "same day", from: "2026-10-19", to: "2026-10-19", want: 1},
+		{name: "weekend only", from: "2026-10-24", to: "2026-10-25", want: 0},
+		{name: "backwards", from: "2026-10-23", to: "2026-10-19", want: -5},
+		{name: "holiday", from: "2026-12-21", to: "2026-12-25", want: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Workdays(day(tt.from), day(tt.to), holidays))
+		})
+	}
+}
+
+func TestAddWorkdays(t *testing.T) {
+	holidays := Holidays{"2026-12-25": "Christmas Day"}
+
+	tests := []struct {
+		name  string
+		start string
+		n     int
+		want  string
+	}{
+		{name: "within the week", start: "2026-10-19", n: 3, want: "2026-10-22"},
+		{name: "over a weekend", start: "2026-10-23", n: 1, want: "2026-10-26"},
+		{name: "from a saturday", start: "2026-10-24", n: 1, want: "2026-10-26"},
+		{name: "backwards", start: "2026-10-26", n: -1, want: "2026-10-23"},
+		{name: "over a holiday", start: "2026-12-24", n: 1, want: "2026-12-28"},
+		{name: "zero", start: "2026-10-24", n: 0, want: "2026-10-24"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AddWorkdays(day(tt.start), tt.n, holidays)
+			assert.Equal(t, tt.want, got.Format(dateLayout))
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     string
+	}{
+		{
+			name: "years to seconds",
+			from: time.Date(2025, 8, 15, 10, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, 10, 18, 14, 30, 15, 0, time.UTC),
+			want: "1y 2mo 3d 4h 30m 15s",
+		},
+		{
+			name: "borrowing a month",
+			from: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			want: "1mo 1d",
+		},
+		{
+			name: "borrowing a day",
+			from: time.Date(2026, 10, 18, 22, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, 10, 19, 1, 0, 0, 0, time.UTC),
+			want: "3h",
+		},
+		{
+			name: "backwards",
+			from: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC),
+			want: "-7d",
+		},
+		{
+			name: "same instant",
+			from: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			want: "0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Diff(tt.from, tt.to).String())
+		})
+	}
+}
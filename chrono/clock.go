@@ -0,0 +1,133 @@
+package chrono
+
+// Clock holds the running value of the time mode with the time zone instants are read and shown in,
+// and the holidays skipped by business days.
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var errNotInstant = errors.New("current is a duration, add a date to it first")
+
+type Clock struct {
+	value    Value
+	loc      *time.Location
+	holidays Holidays
+	now      func() time.Time
+}
+
+// New returns a clock in the local time zone holding a zero duration. now is read by the instants now and today
+func New(now func() time.Time) *Clock {
+	return &Clock{loc: time.Local, holidays: Holidays{}, now: now}
+}
+
+func (c *Clock) Value() Value {
+	return c.value
+}
+
+func (c *Clock) Set(v Value) {
+	if v.instant {
+		v.t = v.t.In(c.loc)
+	}
+	c.value = v
+}
+
+func (c *Clock) Location() *time.Location {
+	return c.loc
+}
+
+// SetLocation switches to the time zone of the tz database, e.g. Asia/Jakarta or UTC.
+// an instant stays the same instant, shown in the new zone
+func (c *Clock) SetLocation(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("unknown time zone %q", name)
+	}
+
+	c.loc = loc
+	c.Set(c.value)
+	return nil
+}
+
+func (c *Clock) Holidays() Holidays {
+	return c.holidays
+}
+
+func (c *Clock) SetHolidays(h Holidays) {
+	c.holidays = h
+}
+
+// Parse reads an instant or a duration in the time zone of the clock. now and today are read from the clock too
+func (c *Clock) Parse(s string) (Value, error) {
+	switch strings.TrimSpace(s) {
+	case "now":
+		return Instant(c.now().In(c.loc)), nil
+	case "today":
+		return Instant(date(c.now().In(c.loc))), nil
+	}
+
+	return Parse(s, c.loc)
+}
+
+func (c *Clock) Add(v Value) error {
+	sum, err := c.value.Add(v)
+	if err != nil {
+		return err
+	}
+
+	c.Set(sum)
+	return nil
+}
+
+func (c *Clock) Subtract(v Value) error {
+	diff, err := c.value.Subtract(v)
+	if err != nil {
+		return err
+	}
+
+	c.Set(diff)
+	return nil
+}
+
+func (c *Clock) Scale(f float64) error {
+	scaled, err := c.value.Scale(f)
+	if err != nil {
+		return err
+	}
+
+	c.Set(scaled)
+	return nil
+}
+
+// Diff is the calendar period from the instant of the clock to t
+func (c *Clock) Diff(t time.Time) (Period, error) {
+	if !c.value.instant {
+		return Period{}, errNotInstant
+	}
+	return Diff(c.value.t, t), nil
+}
+
+// Workdays counts the business days from the instant of the clock to t, both included
+func (c *Clock) Workdays(t time.Time) (int, error) {
+	if !c.value.instant {
+		return 0, errNotInstant
+	}
+	return Workdays(c.value.t, t, c.holidays), nil
+}
+
+// AddWorkdays moves the instant of the clock by n business days
+func (c *Clock) AddWorkdays(n int) error {
+	if !c.value.instant {
+		return errNotInstant
+	}
+
+	c.Set(Instant(AddWorkdays(c.value.t, n, c.holidays)))
+	return nil
+}
+
+func (c *Clock) String() string {
+	return c.value.String()
+}
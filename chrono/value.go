@@ -0,0 +1,232 @@
+package chrono
+
+// Value is the running value of the time mode, either an instant or a duration. instants and durations
+// combine the way they do on a calendar: an instant plus a duration is an instant, the difference of two
+// instants is a duration, and two instants can't be added. a day is always 24 hours.
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+
+	dateLayout    = "2006-01-02"
+	displayLayout = "Mon 2006-01-02 15:04:05 MST"
+)
+
+// instantLayouts are the layouts of a typed instant, read in the time zone of the clock unless they carry an offset
+var instantLayouts = []string{
+	dateLayout,
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+}
+
+var durationUnits = map[string]time.Duration{
+	"w":  Week,
+	"d":  Day,
+	"h":  time.Hour,
+	"m":  time.Minute,
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ns": time.Nanosecond,
+}
+
+var durationTerm = regexp.MustCompile(`^(\d+(?:\.\d+)?)(w|d|h|ms|m|s|us|µs|ns)`)
+
+var ErrInstants = errors.New("instants can't be added, subtract them to get the duration between them")
+
+type Value struct {
+	instant  bool
+	t        time.Time
+	duration time.Duration
+}
+
+// Instant returns the value of the instant t
+func Instant(t time.Time) Value {
+	return Value{instant: true, t: t}
+}
+
+// Duration returns the value of the duration d
+func Duration(d time.Duration) Value {
+	return Value{duration: d}
+}
+
+func (v Value) IsInstant() bool {
+	return v.instant
+}
+
+// Time is the instant of the value, the zero time for a duration
+func (v Value) Time() time.Time {
+	return v.t
+}
+
+// Duration is the duration of the value, 0 for an instant
+func (v Value) Duration() time.Duration {
+	return v.duration
+}
+
+// Parse reads an instant, e.g. 2026-10-18, 2026-10-18 14:30, 2026-10-18T14:30:00+07:00 or the unix timestamp @1760745600,
+// or a duration, e.g. 1h30m, 3d or -1w2d. instants without offset are in loc
+func Parse(s string, loc *time.Location) (Value, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "@") {
+		sec, err := strconv.ParseInt(s[1:], 10, 64)
+		if err != nil {
+			return Value{}, fmt.Errorf("invalid timestamp %q", s)
+		}
+		return Instant(time.Unix(sec, 0).In(loc)), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return Instant(t), nil
+	}
+	for _, layout := range instantLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return Instant(t), nil
+		}
+	}
+
+	d, err := ParseDuration(s)
+	if err != nil {
+		return Value{}, fmt.Errorf("%q is neither a date nor a duration, e.g. 2026-10-18, 2026-10-18 14:30 or 1h30m", s)
+	}
+	return Duration(d), nil
+}
+
+// ParseDuration reads a duration like time.ParseDuration does, with days (d) and weeks (w) on top
+func ParseDuration(s string) (time.Duration, error) {
+	sign, rest := 1.0, s
+	switch {
+	case strings.HasPrefix(rest, "-"):
+		sign, rest = -1, rest[1:]
+	case strings.HasPrefix(rest, "+"):
+		rest = rest[1:]
+	}
+	if rest == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var total float64
+	for rest != "" {
+		m := durationTerm.FindStringSubmatch(rest)
+		if m == nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		n, _ := strconv.ParseFloat(m[1], 64)
+		total += n * float64(durationUnits[m[2]])
+		rest = rest[len(m[0]):]
+	}
+
+	total *= sign
+	if math.Abs(total) > math.MaxInt64 {
+		return 0, fmt.Errorf("duration %q is out of range", s)
+	}
+	return time.Duration(math.Round(total)), nil
+}
+
+// Add adds w to v. an instant and a duration give an instant, two durations a duration
+func (v Value) Add(w Value) (Value, error) {
+	switch {
+	case v.instant && w.instant:
+		return Value{}, ErrInstants
+	case v.instant:
+		return Instant(v.t.Add(w.duration)), nil
+	case w.instant:
+		return Instant(w.t.Add(v.duration)), nil
+	default:
+		return addDurations(v.duration, w.duration)
+	}
+}
+
+// Subtract subtracts w from v. two instants give the duration between them
+func (v Value) Subtract(w Value) (Value, error) {
+	switch {
+	case v.instant && w.instant:
+		d := v.t.Sub(w.t)
+		if d == math.MaxInt64 || d == math.MinInt64 {
+			return Value{}, errors.New("the instants are too far apart")
+		}
+		return Duration(d), nil
+	case v.instant:
+		return Instant(v.t.Add(-w.duration)), nil
+	case w.instant:
+		return Value{}, errors.New("an instant can't be subtracted from a duration")
+	default:
+		return addDurations(v.duration, -w.duration)
+	}
+}
+
+// Scale multiplies a duration by f
+func (v Value) Scale(f float64) (Value, error) {
+	if v.instant {
+		return Value{}, errors.New("an instant can't be multiplied or divided")
+	}
+
+	d := float64(v.duration) * f
+	if math.IsNaN(d) || math.Abs(d) > math.MaxInt64 {
+		return Value{}, errors.New("duration out of range")
+	}
+	return Duration(time.Duration(math.Round(d))), nil
+}
+
+func addDurations(a, b time.Duration) (Value, error) {
+	sum := a + b
+	if (a > 0 && b > 0 && sum < 0) || (a < 0 && b < 0 && sum >= 0) {
+		return Value{}, errors.New("duration out of range")
+	}
+	return Duration(sum), nil
+}
+
+// String writes an instant with its weekday and time zone, and a duration in days, hours, minutes and seconds
+func (v Value) String() string {
+	if v.instant {
+		return v.t.Format(displayLayout)
+	}
+	return FormatDuration(v.duration)
+}
+
+// FormatDuration writes d as e.g. 3d 4h 5m 6.5s
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+
+	sign := ""
+	if d < 0 {
+		sign = "-"
+	}
+	// the magnitude as unsigned, math.MinInt64 has no positive counterpart
+	u := uint64(d)
+	if d < 0 {
+		u = -u
+	}
+
+	parts := []string{}
+	for _, unit := range []struct {
+		size   time.Duration
+		symbol string
+	}{{Day, "d"}, {time.Hour, "h"}, {time.Minute, "m"}} {
+		if n := u / uint64(unit.size); n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, unit.symbol))
+			u %= uint64(unit.size)
+		}
+	}
+	if u > 0 {
+		sec := strconv.FormatFloat(float64(u)/float64(time.Second), 'f', -1, 64)
+		parts = append(parts, sec+"s")
+	}
+
+	return sign + strings.Join(parts, " ")
+}
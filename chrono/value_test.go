@@ -0,0 +1,82 @@
+package chrono
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skip("no tz database")
+	}
+
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{name: "date", s: "2026-10-18", want: "Sun 2026-10-18 00:00:00 WIB"},
+		{name: "date and time", s: "2026-10-18 14:30", want: "Sun 2026-10-18 14:30:00 WIB"},
+		{name: "iso with seconds", s: "2026-10-18T14:30:05", want: "Sun 2026-10-18 14:30:05 WIB"},
+		{name: "rfc3339 keeps its offset", s: "2026-10-18T14:30:00Z", want: "Sun 2026-10-18 14:30:00 UTC"},
+		{name: "unix timestamp", s: "@0", want: "Thu 1970-01-01 07:00:00 WIB"},
+		{name: "duration", s: "1h30m", want: "1h 30m"},
+		{name: "days and weeks", s: "1w2d", want: "9d"},
+		{name: "fractions", s: "1.5d", want: "1d 12h"},
+		{name: "negative duration", s: "-90s", want: "-1m 30s"},
+		{name: "milliseconds", s: "1s500ms", want: "1.5s"},
+		{name: "invalid date", s: "2026-13-01", wantErr: true},
+		{name: "plain number", s: "5", wantErr: true},
+		{name: "unknown unit", s: "3y", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.s, jakarta)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestValue_Arithmetic(t *testing.T) {
+	day := Instant(time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC))
+	later := Instant(time.Date(2026, 10, 20, 12, 30, 0, 0, time.UTC))
+	hours := Duration(36 * time.Hour)
+
+	tests := []struct {
+		name    string
+		got     func() (Value, error)
+		want    string
+		wantErr bool
+	}{
+		{name: "instant plus duration", got: func() (Value, error) { return day.Add(hours) }, want: "Mon 2026-10-19 21:00:00 UTC"},
+		{name: "duration plus instant", got: func() (Value, error) { return hours.Add(day) }, want: "Mon 2026-10-19 21:00:00 UTC"},
+		{name: "durations", got: func() (Value, error) { return hours.Add(Duration(30 * time.Minute)) }, want: "1d 12h 30m"},
+		{name: "instants can't be added", got: func() (Value, error) { return day.Add(later) }, wantErr: true},
+		{name: "instant minus instant", got: func() (Value, error) { return later.Subtract(day) }, want: "2d 3h 30m"},
+		{name: "instant minus duration", got: func() (Value, error) { return day.Subtract(hours) }, want: "Fri 2026-10-16 21:00:00 UTC"},
+		{name: "duration minus instant", got: func() (Value, error) { return hours.Subtract(day) }, wantErr: true},
+		{name: "scale a duration", got: func() (Value, error) { return hours.Scale(0.5) }, want: "18h"},
+		{name: "scale an instant", got: func() (Value, error) { return day.Scale(2) }, wantErr: true},
+		{name: "duration overflow", got: func() (Value, error) { return Duration(1 << 62).Add(Duration(1 << 62)) }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.got()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+// handler of the factorial, gamma and combinatorics commands. a result beyond float64 is an overflow error
+// instead of +Inf. in exact mode factorials, combinations and permutations print every digit, computed on big integers.
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"gitlab.com/atthoriq/calculator-project/calculator"
+	"gitlab.com/atthoriq/calculator-project/combinatorics"
+	"gitlab.com/atthoriq/calculator-project/numtheory"
+)
+
+const (
+	factorial = "fact"
+	gamma     = "gamma"
+	lgamma    = "lgamma"
+	ncr       = "ncr"
+	npr       = "npr"
+	binom     = "binom"
+	exactOp   = "exact"
+
+	exactOn  = "on"
+	exactOff = "off"
+)
+
+// handleCombinatorics handles the factorial, gamma and combinatorics commands
+func (ch *calculatorHandler) handleCombinatorics(op string, args []string) (string, error) {
+	current := ch.calculator.GetResult()
+
+	switch op {
+	case gamma, lgamma:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
+		calc, preview := ch.calculator.Gamma, math.Gamma(current)
+		if op == lgamma {
+			calc = ch.calculator.LogGamma
+			preview, _ = math.Lgamma(current)
+		}
+		if math.IsInf(preview, 0) && !math.IsInf(current, 0) {
+			return "", fmt.Errorf("%s of %v overflows float64", op, current)
+		}
+
+		res := calc().GetResult()
+		return ch.formatCurrent(res), nil
+	case binom:
+		n, err := numtheory.FromFloat(current)
+		if err != nil {
+			return "", fmt.Errorf("current %w", err)
+		}
+		v, err := ch.parseValues(args, 2, 2)
+		if err != nil {
+			return "", err
+		}
+		k, err := numtheory.FromFloat(v[0])
+		if err != nil {
+			return "", err
+		}
+		if n < 0 || k < 0 {
+			return "", fmt.Errorf("binomial probability of %d successes out of %d trials: both have to be positive", k, n)
+		}
+		if v[1] < 0 || v[1] > 1 {
+			return "", fmt.Errorf("invalid probability %s: it has to be between 0 and 1", args[1])
+		}
+
+		res := ch.calculator.Binomial(v[0], v[1]).GetResult()
+		return ch.formatCurrent(res), nil
+	case factorial, ncr, npr:
+		n, err := numtheory.FromFloat(current)
+		if err != nil {
+			if op == factorial {
+				return "", fmt.Errorf("current %w, gamma takes any number", err)
+			}
+			return "", fmt.Errorf("current %w", err)
+		}
+
+		var k int64
+		if op == factorial {
+			if len(args) > 0 {
+				return "", errInvalidInput
+			}
+		} else {
+			integers, err := ch.parseIntegers(args, 1)
+			if err != nil {
+				return "", err
+			}
+			k = integers[0]
+		}
+
+		var exact *big.Int
+		var calc func() calculator.NewCalculator
+		switch op {
+		case factorial:
+			exact, err = combinatorics.Factorial(n)
+			calc = ch.calculator.Factorial
+		case ncr:
+			exact, err = combinatorics.Choose(n, k)
+			calc = func() calculator.NewCalculator { return ch.calculator.Choose(float64(k)) }
+		case npr:
+			exact, err = combinatorics.Permutations(n, k)
+			calc = func() calculator.NewCalculator { return ch.calculator.Permutations(float64(k)) }
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if f, _ := new(big.Float).SetInt(exact).Float64(); math.IsInf(f, 0) {
+			if !ch.exact {
+				return "", fmt.Errorf("%s overflows float64, exact on prints all its %d digits", op, len(exact.String()))
+			}
+			return exact.String() + " (beyond float64, current unchanged)", nil
+		}
+
+		res := calc().GetResult()
+		if ch.exact {
+			return exact.String(), nil
+		}
+		return ch.formatCurrent(res), nil
+	case exactOp:
+		switch {
+		case len(args) == 0:
+			if ch.exact {
+				return exactOn, nil
+			}
+			return exactOff, nil
+		case len(args) > 1 || (args[0] != exactOn && args[0] != exactOff):
+			return "", errInvalidInput
+		}
+
+		ch.exact = args[0] == exactOn
+		return "exact " + args[0], nil
+	default:
+		return "", errInvalidInput
+	}
+}
@@ -0,0 +1,139 @@
+package combinatorics
+
+// factorials, combinations and permutations. the exact functions work on big integers and
+// the float functions round the exact value, which is +Inf when it is out of the float64 range.
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// MaxExact bounds the number of factors multiplied exactly, beyond it the digits take too long to compute
+const MaxExact = 100000
+
+// Factorial is n!
+func Factorial(n int64) (*big.Int, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("factorial of negative %d", n)
+	}
+	if n > MaxExact {
+		return nil, fmt.Errorf("factorial of %d is too large to compute exactly, the limit is %d", n, MaxExact)
+	}
+
+	return new(big.Int).MulRange(1, n), nil
+}
+
+// Choose is the number of combinations of k out of n, 0 when k > n
+func Choose(n, k int64) (*big.Int, error) {
+	if n < 0 || k < 0 {
+		return nil, fmt.Errorf("combinations of %d out of %d: both have to be positive", k, n)
+	}
+	if k > n {
+		return new(big.Int), nil
+	}
+	if min(k, n-k) > MaxExact {
+		return nil, fmt.Errorf("combinations of %d out of %d are too large to compute exactly", k, n)
+	}
+
+	return new(big.Int).Binomial(n, k), nil
+}
+
+// Permutations is the number of ordered arrangements of k out of n, 0 when k > n
+func Permutations(n, k int64) (*big.Int, error) {
+	if n < 0 || k < 0 {
+		return nil, fmt.Errorf("permutations of %d out of %d: both have to be positive", k, n)
+	}
+	if k > n {
+		return new(big.Int), nil
+	}
+	if k > MaxExact {
+		return nil, fmt.Errorf("permutations of %d out of %d are too large to compute exactly", k, n)
+	}
+
+	return new(big.Int).MulRange(n-k+1, n), nil
+}
+
+// FactorialFloat is n! rounded to float64, NaN for negative n
+func FactorialFloat(n int64) float64 {
+	switch {
+	case n < 0:
+		return math.NaN()
+	case n > 170:
+		// 171! is the first factorial beyond the largest float64
+		return math.Inf(1)
+	}
+
+	f, _ := Factorial(n)
+	return toFloat(f)
+}
+
+// ChooseFloat is the number of combinations of k out of n rounded to float64, NaN for negative n or k
+func ChooseFloat(n, k int64) float64 {
+	if n < 0 || k < 0 {
+		return math.NaN()
+	}
+	if k > n {
+		return 0
+	}
+	if min(k, n-k) > 1000 {
+		// it is at least the combinations of 1001 out of 2002, beyond 10^600
+		return math.Inf(1)
+	}
+
+	c, _ := Choose(n, k)
+	return toFloat(c)
+}
+
+// PermutationsFloat is the number of permutations of k out of n rounded to float64, NaN for negative n or k
+func PermutationsFloat(n, k int64) float64 {
+	if n < 0 || k < 0 {
+		return math.NaN()
+	}
+	if k > n {
+		return 0
+	}
+	if k > 170 {
+		// it is at least k!
+		return math.Inf(1)
+	}
+
+	p, _ := Permutations(n, k)
+	return toFloat(p)
+}
+
+// Binomial is the probability of exactly k successes out of n trials with a success probability p.
+// it is computed in log space so large n doesn't overflow the number of combinations
+func Binomial(n, k int64, p float64) float64 {
+	switch {
+	case n < 0 || k < 0 || math.IsNaN(p) || p < 0 || p > 1:
+		return math.NaN()
+	case k > n:
+		return 0
+	case p == 0:
+		if k == 0 {
+			return 1
+		}
+		return 0
+	case p == 1:
+		if k == n {
+			return 1
+		}
+		return 0
+	}
+
+	return math.Exp(logChoose(n, k) + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p))
+}
+
+func logChoose(n, k int64) float64 {
+	a, _ := math.Lgamma(float64(n) + 1)
+	b, _ := math.Lgamma(float64(k) + 1)
+	c, _ := math.Lgamma(float64(n-k) + 1)
+	return a - b - c
+}
+
+// toFloat rounds i to the nearest float64, +Inf when it is too large
+func toFloat(i *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(i).Float64()
+	return f
+}
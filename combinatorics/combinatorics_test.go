@@ -0,0 +1,62 @@
+package combinatorics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExact(t *testing.T) {
+	f, err := Factorial(30)
+	assert.NoError(t, err)
+	assert.Equal(t, "265252859812191058636308480000000", f.String())
+
+	f, err = Factorial(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", f.String())
+
+	c, err := Choose(52, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "2598960", c.String())
+
+	c, err = Choose(3, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", c.String())
+
+	p, err := Permutations(10, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "720", p.String())
+
+	_, err = Factorial(-1)
+	assert.Error(t, err)
+	_, err = Factorial(MaxExact + 1)
+	assert.Error(t, err)
+	_, err = Choose(5, -1)
+	assert.Error(t, err)
+}
+
+func TestFloat(t *testing.T) {
+	assert.Equal(t, float64(3628800), FactorialFloat(10))
+	assert.Equal(t, 7.257415615307999e306, FactorialFloat(170))
+	assert.True(t, math.IsInf(FactorialFloat(171), 1))
+	assert.True(t, math.IsNaN(FactorialFloat(-1)))
+
+	assert.Equal(t, float64(2598960), ChooseFloat(52, 5))
+	assert.Equal(t, float64(9007199254740991), ChooseFloat(9007199254740991, 1))
+	assert.True(t, math.IsInf(ChooseFloat(2000, 1000), 1))
+	assert.Equal(t, float64(720), PermutationsFloat(10, 3))
+	assert.Equal(t, float64(0), PermutationsFloat(3, 10))
+	assert.True(t, math.IsInf(PermutationsFloat(1000, 200), 1))
+}
+
+func TestBinomial(t *testing.T) {
+	assert.InDelta(t, 0.3125, Binomial(5, 2, 0.5), 1e-15)
+	assert.InDelta(t, 0.1171875, Binomial(10, 3, 0.5), 1e-15)
+	assert.Equal(t, float64(1), Binomial(4, 0, 0))
+	assert.Equal(t, float64(0), Binomial(4, 2, 1))
+	assert.True(t, math.IsNaN(Binomial(4, 2, 1.5)))
+
+	// the combinations of 2000 out of 5000 overflow float64 but the probability doesn't
+	assert.False(t, math.IsInf(Binomial(5000, 2000, 0.4), 0))
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/atthoriq/calculator-project/calculator"
+)
+
+func Test_calculatorHandler_Handle_Combinatorics(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "fact",
+			commands: []string{"add 10", "fact"},
+			want:     "3628800.00",
+		},
+		{
+			name:     "fact overflows",
+			commands: []string{"add 171", "fact"},
+			wantErr:  "fact overflows float64, exact on prints all its 310 digits",
+		},
+		{
+			name:     "fact of a fraction",
+			commands: []string{"add 4.5", "fact"},
+			wantErr:  "current 4.5 is not an integer, gamma takes any number",
+		},
+		{
+			name:     "gamma",
+			commands: []string{"add 5", "gamma"},
+			want:     "24.00",
+		},
+		{
+			name:     "gamma overflows",
+			commands: []string{"add 200", "gamma"},
+			wantErr:  "gamma of 200 overflows float64",
+		},
+		{
+			name:     "lgamma doesn't overflow",
+			commands: []string{"add 200", "lgamma"},
+			want:     "857.93",
+		},
+		{
+			name:     "ncr",
+			commands: []string{"add 52", "ncr 5"},
+			want:     "2598960.00",
+		},
+		{
+			name:     "npr",
+			commands: []string{"add 10", "npr 3"},
+			want:     "720.00",
+		},
+		{
+			name:     "binom",
+			commands: []string{"add 5", "binom 2 0.5"},
+			want:     "0.31",
+		},
+		{
+			name:     "binom as a fraction",
+			commands: []string{"frac on", "add 5", "binom 2 0.5"},
+			want:     "0.31 ≈ 5/16",
+		},
+		{
+			name:     "gamma of an uncertain value",
+			commands: []string{"add 5±0.1", "gamma"},
+			want:     "[-Inf, +Inf]",
+		},
+		{
+			name:     "binom with invalid probability",
+			commands: []string{"add 5", "binom 2 150%"},
+			wantErr:  "invalid probability 150%: it has to be between 0 and 1",
+		},
+		{
+			name:     "exact prints every digit",
+			commands: []string{"exact on", "add 25", "fact"},
+			want:     "15511210043330985984000000",
+		},
+		{
+			name:     "exact beyond float64 keeps current",
+			commands: []string{"exact on", "add 2000", "ncr 1000", "subtotal"},
+			want:     "2000.00",
+		},
+		{
+			name:     "exact setting",
+			commands: []string{"exact on", "exact off", "exact"},
+			want:     "off",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleAll(t, tt.commands)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_calculatorHandler_Handle_Exact_Beyond_Float64(t *testing.T) {
+	ch := InitCalculatorHandler(calculator.InitNewCalculator())
+	for _, command := range []string{"exact on", "add 171"} {
+		_, err := ch.Handle(command)
+		assert.NoError(t, err)
+	}
+
+	got, err := ch.Handle("fact")
+	assert.NoError(t, err)
+	digits, note, _ := strings.Cut(got, " ")
+	assert.Len(t, digits, 310)
+	assert.True(t, strings.HasPrefix(digits, "12410180702176678234"))
+	assert.Equal(t, "(beyond float64, current unchanged)", note)
+	assert.Equal(t, float64(171), ch.calculator.GetResult())
+}
@@ -0,0 +1,169 @@
+package main
+
+// handler of the currencies. current can be tagged with a currency code and converted to another one
+// with the rates of a local table loaded by rates <file>. the calculator keeps the currency, so repeat and
+// invert get it right. amounts are shown rounded to the minor unit of their currency, current keeps every digit.
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gitlab.com/atthoriq/calculator-project/currency"
+)
+
+const (
+	currencyOp = "currency"
+	ratesOp    = "rates"
+	minorOp    = "minor"
+
+	currencyNone = "none"
+	dateLayout   = "2006-01-02"
+)
+
+// isCurrencyConversion tells whether convert <to> converts a currency rather than a unit
+func (ch *calculatorHandler) isCurrencyConversion(args []string) bool {
+	if len(args) != 1 || !currency.IsCode(args[0]) {
+		return false
+	}
+	if ch.calculator.GetCurrency() != "" {
+		return true
+	}
+	if ch.rates == nil {
+		return false
+	}
+
+	// let convert tell current has no currency rather than no unit
+	_, ok := ch.rates.Rate(args[0])
+	return ok
+}
+
+// handleCurrency handles currency, rates, minor and the conversion of a currency
+func (ch *calculatorHandler) handleCurrency(op string, args []string) (string, error) {
+	switch op {
+	case convert:
+		return ch.convertCurrency(args[0])
+	case currencyOp:
+		switch {
+		case len(args) == 0:
+			code := ch.calculator.GetCurrency()
+			if code == "" {
+				return currencyNone, nil
+			}
+			return code, nil
+		case len(args) == 1 && args[0] == currencyNone:
+			ch.calculator.SetCurrency("")
+		case len(args) == 1:
+			if !currency.IsCode(args[0]) {
+				return "", fmt.Errorf("%q is not a currency code, e.g. USD", args[0])
+			}
+			ch.calculator.SetCurrency(args[0])
+		default:
+			return "", errInvalidInput
+		}
+
+		res := ch.calculator.GetResult()
+		return ch.formatCurrent(res), nil
+	case ratesOp:
+		switch len(args) {
+		case 0:
+			return ch.printRates()
+		case 1:
+			return ch.loadRates(args[0])
+		}
+	case minorOp:
+		if len(args) != 2 || !currency.IsCode(args[0]) {
+			return "", errInvalidInput
+		}
+
+		digits, err := strconv.Atoi(args[1])
+		if err != nil || digits < 0 || digits > maxDigits {
+			return "", fmt.Errorf("invalid number of digits %q", args[1])
+		}
+		if ch.minors == nil {
+			ch.minors = map[string]int{}
+		}
+		ch.minors[args[0]] = digits
+		return fmt.Sprintf("%s rounds to %d decimals", args[0], digits), nil
+	}
+
+	return "", errInvalidInput
+}
+
+// convertCurrency exchanges current to the currency to
+func (ch *calculatorHandler) convertCurrency(to string) (string, error) {
+	from := ch.calculator.GetCurrency()
+	if from == "" {
+		return "", errors.New("current has no currency, set it with currency <code>")
+	}
+	if ch.rates == nil {
+		return "", errors.New("no rates, load them with rates <file>")
+	}
+
+	rate, date, err := ch.rates.Exchange(from, to)
+	if err != nil {
+		return "", err
+	}
+
+	res := ch.calculator.Exchange(rate, to).GetResult()
+	return fmt.Sprintf("%s (1 %s = %s %s on %s)", ch.formatCurrent(res), from, strconv.FormatFloat(rate, 'g', -1, 64), to, date.Format(dateLayout)), nil
+}
+
+// loadRates replaces the rates by the table of the json or csv file
+func (ch *calculatorHandler) loadRates(name string) (string, error) {
+	read, ok := map[string]func(io.Reader) (*currency.Table, error){
+		".json": currency.ReadJSON,
+		".csv":  currency.ReadCSV,
+	}[strings.ToLower(filepath.Ext(name))]
+	if !ok {
+		return "", fmt.Errorf("unknown rates file %s: use .json or .csv", name)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	table, err := read(f)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+
+	ch.rates = table
+	return fmt.Sprintf("%d rates of %s loaded from %s", len(table.Rates()), table.Base, name), nil
+}
+
+// printRates lists the rates with their date
+func (ch *calculatorHandler) printRates() (string, error) {
+	if ch.rates == nil {
+		return "", errors.New("no rates, load them with rates <file>")
+	}
+
+	lines := []string{fmt.Sprintf("1 %s =", ch.rates.Base)}
+	for _, r := range ch.rates.Rates() {
+		lines = append(lines, fmt.Sprintf("%14s %s on %s", strconv.FormatFloat(r.PerBase, 'g', -1, 64), r.Code, r.Date.Format(dateLayout)))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// minor is the number of decimals amounts of the currency are rounded to
+func (ch *calculatorHandler) minor(code string) int {
+	if digits, ok := ch.minors[code]; ok {
+		return digits
+	}
+	if ch.rates != nil {
+		return ch.rates.Minor(code)
+	}
+	return currency.MinorUnit(code)
+}
+
+// formatAmount formats v in the currency, rounded to its minor unit
+func (ch *calculatorHandler) formatAmount(v float64, code string) string {
+	f := numberFormat{style: formatFixed, digits: ch.minor(code)}
+	return ch.locale.localize(f.format(v)) + " " + code
+}
@@ -0,0 +1,257 @@
+package currency
+
+// exchange rates read from a local table, there's no network access. every rate is the amount of
+// a currency bought by 1 of the base currency of the table, stamped with the date it was taken,
+// so converting between two currencies goes through the base.
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// defaultMinor is the number of decimals of the minor unit of the currencies not in minorUnits
+const defaultMinor = 2
+
+// minorUnits are the ISO 4217 minor units differing from 2 decimals
+var minorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// Rate is the amount of a currency bought by 1 of the base currency on Date
+type Rate struct {
+	Code    string
+	PerBase float64
+	Date    time.Time
+	// Minor is the number of decimals of the minor unit, -1 when the table doesn't set it
+	Minor int
+}
+
+// Table holds the rates of the currencies against a single base currency
+type Table struct {
+	Base  string
+	rates map[string]Rate
+}
+
+// IsCode tells whether s is written as a currency code, i.e. 3 uppercase letters
+func IsCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// MinorUnit is the ISO 4217 number of decimals of the currency
+func MinorUnit(code string) int {
+	if minor, ok := minorUnits[code]; ok {
+		return minor
+	}
+	return defaultMinor
+}
+
+func newTable(base string) (*Table, error) {
+	if !IsCode(base) {
+		return nil, fmt.Errorf("base %q is not a currency code", base)
+	}
+	return &Table{Base: base, rates: map[string]Rate{base: {Code: base, PerBase: 1, Minor: -1}}}, nil
+}
+
+// add puts the rate in the table, keeping the most recent rate of a currency
+func (t *Table) add(r Rate) error {
+	if !IsCode(r.Code) {
+		return fmt.Errorf("%q is not a currency code", r.Code)
+	}
+	if r.PerBase <= 0 || math.IsInf(r.PerBase, 0) || math.IsNaN(r.PerBase) {
+		return fmt.Errorf("%s: rate %v is not positive", r.Code, r.PerBase)
+	}
+	if r.Code == t.Base {
+		if r.PerBase != 1 {
+			return fmt.Errorf("%s: the base currency has rate 1, not %v", r.Code, r.PerBase)
+		}
+		r.Date = time.Time{}
+	}
+	if old, ok := t.rates[r.Code]; ok && old.Date.After(r.Date) {
+		return nil
+	}
+
+	t.rates[r.Code] = r
+	return nil
+}
+
+// Rate returns the rate of the currency
+func (t *Table) Rate(code string) (Rate, bool) {
+	r, ok := t.rates[code]
+	return r, ok
+}
+
+// Rates returns the rates sorted by code, the base currency excluded
+func (t *Table) Rates() []Rate {
+	rates := make([]Rate, 0, len(t.rates))
+	for code, r := range t.rates {
+		if code != t.Base {
+			rates = append(rates, r)
+		}
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Code < rates[j].Code })
+	return rates
+}
+
+// Minor is the number of decimals of the minor unit of the currency, as set by the table or by ISO 4217
+func (t *Table) Minor(code string) int {
+	if r, ok := t.rates[code]; ok && r.Minor >= 0 {
+		return r.Minor
+	}
+	return MinorUnit(code)
+}
+
+// Exchange returns how much of to is bought by 1 of from, and the date of the oldest of both rates
+func (t *Table) Exchange(from, to string) (float64, time.Time, error) {
+	f, ok := t.rates[from]
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("no rate for %s", from)
+	}
+	r, ok := t.rates[to]
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("no rate for %s", to)
+	}
+
+	date := f.Date
+	if date.IsZero() || (!r.Date.IsZero() && r.Date.Before(date)) {
+		date = r.Date
+	}
+	return r.PerBase / f.PerBase, date, nil
+}
+
+type jsonTable struct {
+	Base  string     `json:"base"`
+	Rates []jsonRate `json:"rates"`
+}
+
+type jsonRate struct {
+	Code  string  `json:"code"`
+	Rate  float64 `json:"rate"`
+	Date  string  `json:"date"`
+	Minor *int    `json:"minor,omitempty"`
+}
+
+// ReadJSON reads a table written as {"base": "USD", "rates": [{"code": "EUR", "rate": 0.92, "date": "2026-10-01"}]}.
+// a rate may set the minor unit of its currency with "minor"
+func ReadJSON(r io.Reader) (*Table, error) {
+	var raw jsonTable
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	t, err := newTable(raw.Base)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, jr := range raw.Rates {
+		minor := -1
+		if jr.Minor != nil {
+			minor = *jr.Minor
+		}
+		rate, err := newRate(jr.Code, jr.Rate, jr.Date, minor)
+		if err == nil {
+			err = t.add(rate)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("rate %d: %w", i+1, err)
+		}
+	}
+
+	return t, nil
+}
+
+// ReadCSV reads a table with the header base,code,rate,date and an optional minor column.
+// every row has the same base
+func ReadCSV(r io.Reader) (*Table, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("no header")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"base", "code", "rate", "date"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("no %s column", name)
+		}
+	}
+
+	var t *Table
+	for i, record := range records[1:] {
+		line := i + 2
+		field := func(name string) string {
+			return strings.TrimSpace(record[columns[name]])
+		}
+
+		if t == nil {
+			if t, err = newTable(field("base")); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		} else if field("base") != t.Base {
+			return nil, fmt.Errorf("line %d: base %s differs from %s", line, field("base"), t.Base)
+		}
+
+		value, err := strconv.ParseFloat(field("rate"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid rate %q", line, field("rate"))
+		}
+
+		minor := -1
+		if _, ok := columns["minor"]; ok && field("minor") != "" {
+			if minor, err = strconv.Atoi(field("minor")); err != nil {
+				return nil, fmt.Errorf("line %d: invalid minor unit %q", line, field("minor"))
+			}
+		}
+
+		rate, err := newRate(field("code"), value, field("date"), minor)
+		if err == nil {
+			err = t.add(rate)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if t == nil {
+		return nil, errors.New("no rates")
+	}
+
+	return t, nil
+}
+
+func newRate(code string, value float64, date string, minor int) (Rate, error) {
+	d, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return Rate{}, fmt.Errorf("%s: invalid date %q, expected YYYY-MM-DD", code, date)
+	}
+	if minor < -1 || minor > 4 {
+		return Rate{}, fmt.Errorf("%s: minor unit of %d decimals", code, minor)
+	}
+
+	return Rate{Code: code, PerBase: value, Date: d, Minor: minor}, nil
+}
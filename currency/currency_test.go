@@ -0,0 +1,118 @@
+package currency
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ratesJSON = `{
+	"base": "USD",
+	"rates": [
+		{"code": "EUR", "rate": 0.9, "date": "2026-10-01"},
+		{"code": "EUR", "rate": 0.8, "date": "2026-09-01"},
+		{"code": "JPY", "rate": 150, "date": "2026-09-30"},
+		{"code": "KWD", "rate": 0.3, "date": "2026-10-01", "minor": 2}
+	]
+}`
+
+func TestReadJSON(t *testing.T) {
+	table, err := ReadJSON(strings.NewReader(ratesJSON))
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", table.Base)
+
+	codes := []string{}
+	for _, r := range table.Rates() {
+		codes = append(codes, r.Code)
+	}
+	assert.Equal(t, []string{"EUR", "JPY", "KWD"}, codes)
+
+	eur, ok := table.Rate("EUR")
+	assert.True(t, ok)
+	assert.Equal(t, 0.9, eur.PerBase, "the most recent rate is kept")
+}
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		want    int
+		wantErr string
+	}{
+		{
+			name: "columns in any order",
+			csv:  "code,base,date,rate,minor\nEUR,USD,2026-10-01,0.9,\nJPY,USD,2026-10-01,150,0\n",
+			want: 2,
+		},
+		{
+			name:    "missing column",
+			csv:     "base,code,rate\nUSD,EUR,0.9\n",
+			wantErr: "no date column",
+		},
+		{
+			name:    "mixed bases",
+			csv:     "base,code,rate,date\nUSD,EUR,0.9,2026-10-01\nEUR,JPY,160,2026-10-01\n",
+			wantErr: "line 3: base EUR differs from USD",
+		},
+		{
+			name:    "invalid date",
+			csv:     "base,code,rate,date\nUSD,EUR,0.9,01/10/2026\n",
+			wantErr: `line 2: EUR: invalid date "01/10/2026", expected YYYY-MM-DD`,
+		},
+		{
+			name:    "negative rate",
+			csv:     "base,code,rate,date\nUSD,EUR,-0.9,2026-10-01\n",
+			wantErr: "line 2: EUR: rate -0.9 is not positive",
+		},
+		{
+			name:    "invalid code",
+			csv:     "base,code,rate,date\nUSD,euro,0.9,2026-10-01\n",
+			wantErr: `line 2: "euro" is not a currency code`,
+		},
+		{
+			name:    "no rates",
+			csv:     "base,code,rate,date\n",
+			wantErr: "no rates",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := ReadCSV(strings.NewReader(tt.csv))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, table.Rates(), tt.want)
+		})
+	}
+}
+
+func TestTable_Exchange(t *testing.T) {
+	table, err := ReadJSON(strings.NewReader(ratesJSON))
+	assert.NoError(t, err)
+
+	rate, date, err := table.Exchange("EUR", "JPY")
+	assert.NoError(t, err)
+	assert.InDelta(t, 150/0.9, rate, 1e-12)
+	assert.Equal(t, "2026-09-30", date.Format(dateLayout), "the date of the oldest rate")
+
+	rate, date, err = table.Exchange("USD", "EUR")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.9, rate)
+	assert.Equal(t, "2026-10-01", date.Format(dateLayout))
+
+	_, _, err = table.Exchange("USD", "GBP")
+	assert.EqualError(t, err, "no rate for GBP")
+}
+
+func TestTable_Minor(t *testing.T) {
+	table, err := ReadJSON(strings.NewReader(ratesJSON))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, table.Minor("EUR"))
+	assert.Equal(t, 0, table.Minor("JPY"))
+	assert.Equal(t, 2, table.Minor("KWD"), "set by the table")
+	assert.Equal(t, 3, table.Minor("BHD"))
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_calculatorHandler_Handle_Currency(t *testing.T) {
+	rates := filepath.Join(t.TempDir(), "rates.csv")
+	content := "base,code,rate,date\nUSD,EUR,0.9,2026-10-01\nUSD,JPY,150.5,2026-09-30\nUSD,KWD,0.30712,2026-10-01\n"
+	if err := os.WriteFile(rates, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "load rates",
+			commands: []string{"rates " + rates},
+			want:     "3 rates of USD loaded from " + rates,
+		},
+		{
+			name:     "list rates",
+			commands: []string{"rates " + rates, "rates"},
+			want: "1 USD =\n" +
+				"           0.9 EUR on 2026-10-01\n" +
+				"         150.5 JPY on 2026-09-30\n" +
+				"       0.30712 KWD on 2026-10-01",
+		},
+		{
+			name:     "tag current",
+			commands: []string{"add 12.5", "currency USD"},
+			want:     "12.50 USD",
+		},
+		{
+			name:     "show the currency",
+			commands: []string{"add 12.5", "currency EUR", "currency"},
+			want:     "EUR",
+		},
+		{
+			name:     "convert",
+			commands: []string{"rates " + rates, "add 100", "currency USD", "convert EUR"},
+			want:     "90.00 EUR (1 USD = 0.9 EUR on 2026-10-01)",
+		},
+		{
+			name:     "convert through the base rounds to the minor unit",
+			commands: []string{"rates " + rates, "add 100", "currency EUR", "convert JPY"},
+			want:     "16722 JPY (1 EUR = 167.22222222222223 JPY on 2026-09-30)",
+		},
+		{
+			name:     "three decimals",
+			commands: []string{"rates " + rates, "add 100", "currency USD", "convert KWD"},
+			want:     "30.712 KWD (1 USD = 0.30712 KWD on 2026-10-01)",
+		},
+		{
+			name:     "configured minor unit",
+			commands: []string{"rates " + rates, "minor KWD 1", "add 100", "currency USD", "convert KWD"},
+			want:     "30.7 KWD (1 USD = 0.30712 KWD on 2026-10-01)",
+		},
+		{
+			name:     "arithmetic keeps the currency",
+			commands: []string{"add 100", "currency EUR", "multiply 1.5"},
+			want:     "150.00 EUR",
+		},
+		{
+			name:     "repeat skips convert",
+			commands: []string{"rates " + rates, "currency USD", "add 100", "convert EUR", "repeat 1"},
+			want:     "90.00 EUR",
+		},
+		{
+			name:     "invert restores the currency",
+			commands: []string{"rates " + rates, "currency USD", "add 100", "convert EUR", "invert"},
+			want:     "0.00 USD",
+		},
+		{
+			name:     "invert of a convert",
+			commands: []string{"rates " + rates, "add 100", "currency USD", "convert EUR", "multiply 2", "invert"},
+			want:     "0.00",
+		},
+		{
+			name:     "convert without currency",
+			commands: []string{"rates " + rates, "add 100", "convert EUR"},
+			wantErr:  "current has no currency, set it with currency <code>",
+		},
+		{
+			name:     "convert without rates",
+			commands: []string{"add 100", "currency USD", "convert EUR"},
+			wantErr:  "no rates, load them with rates <file>",
+		},
+		{
+			name:     "convert to an unknown currency",
+			commands: []string{"rates " + rates, "add 100", "currency USD", "convert GBP"},
+			wantErr:  "no rate for GBP",
+		},
+		{
+			name:     "convert a unit is still a unit",
+			commands: []string{"rates " + rates, "add 1 km", "convert m"},
+			want:     "1000.00 m",
+		},
+		{
+			name:     "invalid code",
+			commands: []string{"currency usd"},
+			wantErr:  `"usd" is not a currency code, e.g. USD`,
+		},
+		{
+			name:     "unknown rates file",
+			commands: []string{"rates rates.txt"},
+			wantErr:  "unknown rates file rates.txt: use .json or .csv",
+		},
+		{
+			name:     "cancel clears the currency",
+			commands: []string{"add 100", "currency USD", "cancel", "currency"},
+			want:     "none",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleAll(t, tt.commands)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
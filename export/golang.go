@@ -0,0 +1,151 @@
+package export
+
+// generating go source from the calculator history so a REPL session can be pasted into production code.
+// the function takes the starting value and runs the recorded operations in the same order the calculator did,
+// and the test asserts the function reproduces the calculator result.
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gitlab.com/atthoriq/calculator-project/calculator"
+)
+
+// Go returns the source of a function named funcName reproducing the steps, followed by the source of its test.
+// input is the value the steps started from and want is the result the calculator produced.
+func Go(funcName string, steps []calculator.Step, input, want float64) (source string, test string, err error) {
+	if !token.IsIdentifier(funcName) || token.IsKeyword(funcName) {
+		return "", "", fmt.Errorf("invalid function name %q", funcName)
+	}
+
+	var body strings.Builder
+	usesMath := false
+	for _, step := range steps {
+		line, err := goStatement(step)
+		if err != nil {
+			return "", "", err
+		}
+		usesMath = usesMath || strings.Contains(line, "math.")
+		body.WriteString("\t" + line + "\n")
+	}
+
+	var src bytes.Buffer
+	src.WriteString("package main\n\n")
+	if usesMath {
+		src.WriteString("import \"math\"\n\n")
+	}
+	fmt.Fprintf(&src, "// %s reproduces the operations recorded by the calculator.\n", funcName)
+	src.WriteString("// multiplications are converted explicitly to float64 so they are never fused into an FMA,\n")
+	src.WriteString("// which keeps the result identical to the calculator.\n")
+	fmt.Fprintf(&src, "func %s(x float64) float64 {\n%s\treturn x\n}\n", funcName, body.String())
+
+	var tst bytes.Buffer
+	tst.WriteString("package main\n\nimport (\n\t\"math\"\n\t\"testing\"\n)\n\n")
+	fmt.Fprintf(&tst, "func Test%s(t *testing.T) {\n", exportedName(funcName))
+	fmt.Fprintf(&tst, "\tgot := %s(%s)\n", funcName, goFloat(input))
+	fmt.Fprintf(&tst, "\twant := float64(%s)\n", goFloat(want))
+	tst.WriteString("\tif got != want && !(math.IsNaN(got) && math.IsNaN(want)) {\n")
+	fmt.Fprintf(&tst, "\t\tt.Errorf(\"%s() = %%v, want %%v\", got, want)\n", funcName)
+	tst.WriteString("\t}\n}\n")
+
+	formattedSrc, err := format.Source(src.Bytes())
+	if err != nil {
+		return "", "", err
+	}
+	formattedTest, err := format.Source(tst.Bytes())
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(formattedSrc), string(formattedTest), nil
+}
+
+// goStatement returns the go statement doing the step on x
+func goStatement(step calculator.Step) (string, error) {
+	arg := func() string {
+		return goFloat(step.Args[0])
+	}
+
+	switch step.Op {
+	case calculator.OpAdd:
+		return fmt.Sprintf("x = x + %s", arg()), nil
+	case calculator.OpSubtract:
+		return fmt.Sprintf("x = x - %s", arg()), nil
+	case calculator.OpMultiply, calculator.OpExchange:
+		return fmt.Sprintf("x = float64(x * %s)", arg()), nil
+	case calculator.OpDivide:
+		if step.Args[0] == 0 {
+			return "x = math.NaN()", nil
+		}
+		return fmt.Sprintf("x = float64(x / %s)", arg()), nil
+	case calculator.OpConvert:
+		if step.Args[1] == 0 {
+			return fmt.Sprintf("x = float64(x * %s)", arg()), nil
+		}
+		return fmt.Sprintf("x = float64(x*%s) + %s", arg(), goFloat(step.Args[1])), nil
+	case calculator.OpAbs:
+		return "x = math.Abs(x)", nil
+	case calculator.OpRoot:
+		switch step.Args[0] {
+		case 2:
+			return "x = math.Sqrt(x)", nil
+		case 3:
+			return "x = math.Cbrt(x)", nil
+		default:
+			return "x = math.NaN()", nil
+		}
+	case calculator.OpPow:
+		return fmt.Sprintf("x = math.Pow(x, %s)", arg()), nil
+	case calculator.OpAddPercent, calculator.OpMarkup:
+		return fmt.Sprintf("x = x + float64(x*%s)/100", arg()), nil
+	case calculator.OpSubtractPercent, calculator.OpDiscount:
+		return fmt.Sprintf("x = x - float64(x*%s)/100", arg()), nil
+	case calculator.OpPercentOf:
+		return fmt.Sprintf("x = float64(x*%s) / 100", arg()), nil
+	case calculator.OpMargin:
+		if step.Args[0] == 100 {
+			return "x = math.NaN()", nil
+		}
+		// the divisor is computed here the way the calculator does, a go constant expression would be exact instead
+		return fmt.Sprintf("x = x / %s", goFloat(1-step.Args[0]/100)), nil
+	case calculator.OpGamma:
+		return "x = math.Gamma(x)", nil
+	case calculator.OpLogGamma:
+		return "x, _ = math.Lgamma(x)", nil
+	case calculator.OpPercentChange:
+		return fmt.Sprintf("if x == 0 {\n\t\tx = math.NaN()\n\t} else {\n\t\tx = (%s - x) / x * 100\n\t}", arg()), nil
+	}
+
+	return "", errors.New("operation " + step.Op + " can't be exported to go")
+}
+
+// goFloat returns a go expression for f that round-trips exactly
+func goFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "math.NaN()"
+	case math.IsInf(f, 1):
+		return "math.Inf(1)"
+	case math.IsInf(f, -1):
+		return "math.Inf(-1)"
+	}
+
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if f < 0 {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+func exportedName(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
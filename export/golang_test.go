@@ -0,0 +1,158 @@
+package export
+
+import (
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitlab.com/atthoriq/calculator-project/calculator"
+	"gitlab.com/atthoriq/calculator-project/units"
+)
+
+func TestGo(t *testing.T) {
+	type args struct {
+		funcName string
+		ops      func(c calculator.NewCalculator)
+	}
+	tests := []struct {
+		name     string
+		args     args
+		contains []string
+		wantErr  bool
+	}{
+		{
+			name: "arithmetic operations",
+			args: args{
+				funcName: "scale",
+				ops: func(c calculator.NewCalculator) {
+					c.Add(5).Multiply(3).Subtract(0.1).Divide(7)
+				},
+			},
+			contains: []string{"func scale(x float64) float64 {", "x = float64(x * 3)", "x = x - 0.1", "func TestScale(t *testing.T) {"},
+		},
+		{
+			name: "math functions",
+			args: args{
+				funcName: "Shape",
+				ops: func(c calculator.NewCalculator) {
+					c.Subtract(12).Abs().Root(2).Root(3).Pow(2.5).Divide(0)
+				},
+			},
+			contains: []string{"import \"math\"", "math.Abs(x)", "math.Sqrt(x)", "math.Cbrt(x)", "math.Pow(x, 2.5)", "x = math.NaN()"},
+		},
+		{
+			name: "conversions",
+			args: args{
+				funcName: "fahrenheit",
+				ops: func(c calculator.NewCalculator) {
+					c.Add(20).Convert(1.8, 32, units.None).Convert(0.5, 0, units.None)
+				},
+			},
+			contains: []string{"x = float64(x*1.8) + 32", "x = float64(x * 0.5)"},
+		},
+		{
+			name: "no history",
+			args: args{
+				funcName: "identity",
+				ops:      func(c calculator.NewCalculator) {},
+			},
+			contains: []string{"func identity(x float64) float64 {\n\treturn x\n}"},
+		},
+		{
+			name: "function name is a keyword",
+			args: args{
+				funcName: "func",
+				ops:      func(c calculator.NewCalculator) {},
+			},
+			wantErr: true,
+		},
+		{
+			name: "function name is not an identifier",
+			args: args{
+				funcName: "1st",
+				ops:      func(c calculator.NewCalculator) {},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := calculator.InitNewCalculator()
+			tt.args.ops(c)
+			steps := c.GetHistory()
+			source, test, err := Go(tt.args.funcName, steps, 0, c.GetResult())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Go() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(source+test, want) {
+					t.Errorf("Go() = %v%v, want it to contain %v", source, test, want)
+				}
+			}
+		})
+	}
+}
+
+// the generated test is compiled and run, proving the function reproduces the calculator bit for bit
+func TestGo_Compiles(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  func(c calculator.NewCalculator)
+	}{
+		{
+			name: "arithmetic and math functions",
+			ops: func(c calculator.NewCalculator) {
+				c.Add(math.Pi).Multiply(1.1).Add(0.3).Pow(3).Root(2).Divide(3).Subtract(-2).Multiply(0)
+			},
+		},
+		{
+			name: "percentages",
+			ops: func(c calculator.NewCalculator) {
+				c.Add(199.99).AddPercent(15).SubtractPercent(3.3).Markup(12.5).Margin(33).Discount(7).PercentOf(42).PercentChange(17.3)
+			},
+		},
+		{
+			name: "gamma functions",
+			ops: func(c calculator.NewCalculator) {
+				c.Add(4.5).Gamma().LogGamma().Multiply(-3).Gamma()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if testing.Short() {
+				t.Skip("runs the go toolchain")
+			}
+
+			c := calculator.InitNewCalculator()
+			tt.ops(c)
+			source, test, err := Go("session", c.GetHistory(), 0, c.GetResult())
+			if err != nil {
+				t.Fatalf("Go() error = %v", err)
+			}
+
+			dir := t.TempDir()
+			files := map[string]string{
+				"go.mod":          "module session\n\ngo 1.21\n",
+				"session.go":      source,
+				"session_test.go": test,
+			}
+			for name, content := range files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cmd := exec.Command("go", "test", "./...")
+			cmd.Dir = dir
+			cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("generated test failed: %v\n%s\n%s", err, out, source)
+			}
+		})
+	}
+}
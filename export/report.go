@@ -0,0 +1,184 @@
+package export
+
+// reports of the calculator history, one row per step, to be pasted into tickets.
+// the json report keeps the arguments, the unit and the currency of every step so it can be read back and replayed.
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/atthoriq/calculator-project/calculator"
+	"gitlab.com/atthoriq/calculator-project/units"
+)
+
+var reportHeader = []string{"index", "op", "operand", "before", "after", "timestamp"}
+
+// CSV writes the steps as comma separated values with a header row
+func CSV(w io.Writer, steps []calculator.Step) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(reportHeader); err != nil {
+		return err
+	}
+	for i, step := range steps {
+		if err := cw.Write(reportRow(i, step)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Markdown writes the steps as a markdown table
+func Markdown(w io.Writer, steps []calculator.Step) error {
+	lines := []string{
+		"| " + strings.Join(reportHeader, " | ") + " |",
+		"|" + strings.Repeat(" --- |", len(reportHeader)),
+	}
+	for i, step := range steps {
+		lines = append(lines, "| "+strings.Join(reportRow(i, step), " | ")+" |")
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+func reportRow(i int, step calculator.Step) []string {
+	operands := make([]string, len(step.Args))
+	for j, arg := range step.Args {
+		operands[j] = formatFloat(arg)
+	}
+	if !step.Unit.IsNone() {
+		operands = append(operands, step.Unit.String())
+	}
+	if step.Currency != "" {
+		operands = append(operands, step.Currency)
+	}
+
+	return []string{
+		strconv.Itoa(i + 1),
+		step.Op,
+		strings.Join(operands, " "),
+		formatFloat(step.Before),
+		formatFloat(step.After),
+		step.At.Format(time.RFC3339Nano),
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+type jsonReport struct {
+	Steps []jsonStep `json:"steps"`
+}
+
+type jsonStep struct {
+	Index     int       `json:"index"`
+	Op        string    `json:"op"`
+	Args      []number  `json:"args"`
+	Unit      string    `json:"unit,omitempty"`
+	Currency  string    `json:"currency,omitempty"`
+	Before    number    `json:"before"`
+	After     number    `json:"after"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// number is a float64 that survives json even when it is NaN or infinite, those are written as strings
+type number float64
+
+func (n number) MarshalJSON() ([]byte, error) {
+	f := float64(n)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return json.Marshal(formatFloat(f))
+	}
+	return json.Marshal(f)
+}
+
+func (n *number) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", s)
+		}
+		*n = number(f)
+		return nil
+	}
+
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	*n = number(f)
+	return nil
+}
+
+// JSON writes the steps as an indented json document which can be read back by ReadJSON
+func JSON(w io.Writer, steps []calculator.Step) error {
+	report := jsonReport{Steps: make([]jsonStep, len(steps))}
+	for i, step := range steps {
+		args := make([]number, len(step.Args))
+		for j, arg := range step.Args {
+			args[j] = number(arg)
+		}
+
+		report.Steps[i] = jsonStep{
+			Index:     i + 1,
+			Op:        step.Op,
+			Args:      args,
+			Unit:      step.Unit.String(),
+			Currency:  step.Currency,
+			Before:    number(step.Before),
+			After:     number(step.After),
+			Timestamp: step.At,
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// ReadJSON reads steps written by JSON
+func ReadJSON(r io.Reader) ([]calculator.Step, error) {
+	var report jsonReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("invalid json report: %w", err)
+	}
+
+	steps := make([]calculator.Step, len(report.Steps))
+	for i, s := range report.Steps {
+		args := make([]float64, len(s.Args))
+		for j, arg := range s.Args {
+			args[j] = float64(arg)
+		}
+
+		unit := units.None
+		if s.Unit != "" {
+			u, err := units.Parse(s.Unit)
+			if err != nil {
+				return nil, fmt.Errorf("invalid json report: step %d: %w", i+1, err)
+			}
+			unit = u
+		}
+
+		steps[i] = calculator.Step{
+			Op:       s.Op,
+			Args:     args,
+			Unit:     unit,
+			Currency: s.Currency,
+			Before:   float64(s.Before),
+			After:    float64(s.After),
+			At:       s.Timestamp,
+		}
+	}
+
+	return steps, nil
+}
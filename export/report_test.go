@@ -0,0 +1,139 @@
+package export
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/atthoriq/calculator-project/calculator"
+	"gitlab.com/atthoriq/calculator-project/units"
+)
+
+var reportSteps = []calculator.Step{
+	{Op: calculator.OpAdd, Args: []float64{5}, Before: 0, After: 5, At: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)},
+	{Op: calculator.OpAbs, Args: []float64{}, Before: 5, After: 5, At: time.Date(2026, 10, 18, 9, 0, 1, 0, time.UTC)},
+	{Op: calculator.OpDivide, Args: []float64{0}, Before: 5, After: math.NaN(), At: time.Date(2026, 10, 18, 9, 0, 2, 0, time.UTC)},
+}
+
+var (
+	km, _ = units.Parse("km")
+	mi, _ = units.Parse("mi")
+)
+
+func TestCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := CSV(&buf, reportSteps)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"index,op,operand,before,after,timestamp",
+		"1,add,5,0,5,2026-10-18T09:00:00Z",
+		"2,abs,,5,5,2026-10-18T09:00:01Z",
+		"3,divide,0,5,NaN,2026-10-18T09:00:02Z",
+		"",
+	}, "\n"), buf.String())
+
+	buf.Reset()
+	err = CSV(&buf, []calculator.Step{{Op: calculator.OpMultiply, Args: []float64{2}, Unit: km, Before: 3, After: 6, At: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)}})
+	assert.NoError(t, err)
+	assert.Equal(t, "index,op,operand,before,after,timestamp\n1,multiply,2 km,3,6,2026-10-18T09:00:00Z\n", buf.String())
+}
+
+func TestMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	err := Markdown(&buf, reportSteps[:2])
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"| index | op | operand | before | after | timestamp |",
+		"| --- | --- | --- | --- | --- | --- |",
+		"| 1 | add | 5 | 0 | 5 | 2026-10-18T09:00:00Z |",
+		"| 2 | abs |  | 5 | 5 | 2026-10-18T09:00:01Z |",
+		"",
+	}, "\n"), buf.String())
+}
+
+func TestJSON_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []calculator.Step
+	}{
+		{
+			name:  "no steps",
+			steps: []calculator.Step{},
+		},
+		{
+			name: "steps with units and currencies",
+			steps: []calculator.Step{
+				{Op: calculator.OpAdd, Args: []float64{5}, Unit: km, Before: 0, After: 5},
+				{Op: calculator.OpConvert, Args: []float64{0.621371192237334, 0}, Unit: mi, Before: 5, After: 3.10685596118667},
+				{Op: calculator.OpExchange, Args: []float64{0.9}, Currency: "EUR", Before: 100, After: 90},
+			},
+		},
+		{
+			name:  "steps with non finite values",
+			steps: append(reportSteps, calculator.Step{Op: calculator.OpMultiply, Args: []float64{math.Inf(-1)}, Before: math.NaN(), After: math.NaN()}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := JSON(&buf, tt.steps); err != nil {
+				t.Fatalf("JSON() error = %v", err)
+			}
+
+			got, err := ReadJSON(&buf)
+			if err != nil {
+				t.Fatalf("ReadJSON() error = %v", err)
+			}
+			assert.Len(t, got, len(tt.steps))
+			for i := range got {
+				assert.Equal(t, tt.steps[i].Op, got[i].Op)
+				assert.Equal(t, tt.steps[i].Unit.String(), got[i].Unit.String())
+				assert.Equal(t, tt.steps[i].Currency, got[i].Currency)
+				assert.Len(t, got[i].Args, len(tt.steps[i].Args))
+				for j := range got[i].Args {
+					assert.True(t, floatEqual(tt.steps[i].Args[j], got[i].Args[j]))
+				}
+				assert.True(t, floatEqual(tt.steps[i].Before, got[i].Before))
+				assert.True(t, floatEqual(tt.steps[i].After, got[i].After))
+				assert.True(t, tt.steps[i].At.Equal(got[i].At))
+			}
+		})
+	}
+}
+
+func TestReadJSON_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "not json",
+			input: "index,op",
+		},
+		{
+			name:  "unknown unit",
+			input: `{"steps":[{"op":"add","args":[5],"unit":"parsec"}]}`,
+		},
+		{
+			name:  "number is not a float",
+			input: `{"steps":[{"op":"add","args":["five"]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadJSON(strings.NewReader(tt.input)); err == nil {
+				t.Errorf("ReadJSON() error = nil, want error")
+			}
+		})
+	}
+}
+
+func floatEqual(a, b float64) bool {
+	if math.IsNaN(a) && math.IsNaN(b) {
+		return true
+	}
+	return a == b
+}
@@ -0,0 +1,176 @@
+package main
+
+// handler of the time value of money commands. they print the result without touching current,
+// rates are per period and can be typed as fraction or percentage, e.g. 0.05 or 5%.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gitlab.com/atthoriq/calculator-project/finance"
+)
+
+const (
+	compoundOp = "compound"
+	fvOp       = "fv"
+	pvOp       = "pv"
+	pmtOp      = "pmt"
+	nperOp     = "nper"
+	rateOp     = "rate"
+	npvOp      = "npv"
+	irrOp      = "irr"
+	amortizeOp = "amortize"
+
+	timingBegin = "begin"
+	timingEnd   = "end"
+)
+
+// handleFinance handles the financial commands
+func (ch *calculatorHandler) handleFinance(op string, args []string) (string, error) {
+	switch op {
+	case compoundOp:
+		v, err := ch.parseValues(args, 4, 4)
+		if err != nil {
+			return "", err
+		}
+		if v[2] != float64(int(v[2])) {
+			return "", fmt.Errorf("compounding %s times a year: it has to be an integer", args[2])
+		}
+
+		res, err := finance.Compound(v[0], v[1], int(v[2]), v[3])
+		if err != nil {
+			return "", err
+		}
+		return ch.format(res), nil
+	case fvOp, pvOp, pmtOp, nperOp, rateOp:
+		args, when, err := parseTiming(args)
+		if err != nil {
+			return "", err
+		}
+		v, err := ch.parseValues(args, 3, 4)
+		if err != nil {
+			return "", err
+		}
+		// the optional value is the other end of the money, pv for fv and fv for the rest
+		v = append(v, 0)
+
+		var res float64
+		switch op {
+		case fvOp:
+			res = finance.FV(v[0], v[1], v[2], v[3], when)
+		case pvOp:
+			res = finance.PV(v[0], v[1], v[2], v[3], when)
+		case pmtOp:
+			res = finance.PMT(v[0], v[1], v[2], v[3], when)
+		case nperOp:
+			res, err = finance.NPER(v[0], v[1], v[2], v[3], when)
+		case rateOp:
+			res, err = finance.Rate(v[0], v[1], v[2], v[3], when)
+		}
+		if err != nil {
+			return "", err
+		}
+		return ch.format(res), nil
+	case npvOp:
+		v, err := ch.parseValues(args, 2, -1)
+		if err != nil {
+			return "", err
+		}
+
+		return ch.format(finance.NPV(v[0], v[1:])), nil
+	case irrOp:
+		v, err := ch.parseValues(args, 2, -1)
+		if err != nil {
+			return "", err
+		}
+
+		res, err := finance.IRR(v)
+		if err != nil {
+			return "", err
+		}
+		return ch.format(res), nil
+	case amortizeOp:
+		v, err := ch.parseValues(args, 3, 3)
+		if err != nil {
+			return "", err
+		}
+		if v[1] > finance.MaxPeriods {
+			return "", fmt.Errorf("amortizing in %s periods: the limit is %d", args[1], finance.MaxPeriods)
+		}
+		if v[1] != float64(int(v[1])) {
+			return "", fmt.Errorf("amortizing in %s periods: it has to be an integer", args[1])
+		}
+
+		schedule, err := finance.Amortize(v[0], int(v[1]), v[2])
+		if err != nil {
+			return "", err
+		}
+		return ch.scheduleTable(schedule), nil
+	default:
+		return "", errInvalidInput
+	}
+}
+
+// parseValues reads every argument as a value, expecting between min and max of them. max -1 is unlimited
+func (ch *calculatorHandler) parseValues(args []string, min, max int) ([]float64, error) {
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		return nil, errInvalidInput
+	}
+
+	values := make([]float64, 0, len(args))
+	for _, arg := range args {
+		o, err := ch.locale.parseLiteral(arg)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, o.value)
+	}
+	return values, nil
+}
+
+// parseTiming cuts the optional trailing begin or end off the arguments, payments are at the end by default
+func parseTiming(args []string) ([]string, finance.Timing, error) {
+	if len(args) == 0 {
+		return args, finance.End, nil
+	}
+
+	switch args[len(args)-1] {
+	case timingBegin:
+		return args[:len(args)-1], finance.Begin, nil
+	case timingEnd:
+		return args[:len(args)-1], finance.End, nil
+	default:
+		return args, finance.End, nil
+	}
+}
+
+// scheduleTable prints the amortization schedule as right aligned columns followed by the totals
+func (ch *calculatorHandler) scheduleTable(schedule []finance.Payment) string {
+	rows := [][]string{{"period", "payment", "interest", "principal", "balance"}}
+	var payment, interest, principal float64
+	for _, p := range schedule {
+		rows = append(rows, []string{strconv.Itoa(p.Period), ch.format(p.Payment), ch.format(p.Interest), ch.format(p.Principal), ch.format(p.Balance)})
+		payment += p.Payment
+		interest += p.Interest
+		principal += p.Principal
+	}
+	rows = append(rows, []string{"total", ch.format(payment), ch.format(interest), ch.format(principal), ""})
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len([]rune(cell)))
+		}
+	}
+
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = strings.Repeat(" ", widths[i]-len([]rune(cell))) + cell
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(cells, "  "), " "))
+	}
+	return strings.Join(lines, "\n")
+}
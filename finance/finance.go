@@ -0,0 +1,220 @@
+package finance
+
+// time value of money. it follows the sign convention of spreadsheets: money paid out is negative and
+// money received is positive, e.g. a loan of 1000 has pv 1000 and negative payments.
+// rate is the interest rate per period as fraction, e.g. 0.05 for 5%.
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Timing tells whether payments are made at the end or at the beginning of each period
+type Timing int
+
+const (
+	End Timing = iota
+	Begin
+)
+
+const (
+	maxIterations = 100
+	tolerance     = 1e-12
+)
+
+// MaxPeriods bounds the rows of an amortization schedule, it is daily payments for more than 270 years
+const MaxPeriods = 100000
+
+var ErrNoSolution = errors.New("no solution")
+
+// Payment is a row of an amortization schedule
+type Payment struct {
+	Period    int
+	Payment   float64
+	Interest  float64
+	Principal float64
+	Balance   float64
+}
+
+// Compound is the principal with the annual rate compounded perYear times a year for years
+func Compound(principal, rate float64, perYear int, years float64) (float64, error) {
+	if perYear < 1 {
+		return 0, fmt.Errorf("compounding %d times a year: it has to be at least once", perYear)
+	}
+
+	n := float64(perYear)
+	return principal * math.Pow(1+rate/n, n*years), nil
+}
+
+// FV is the future value of pv and nper payments of pmt
+func FV(rate, nper, pmt, pv float64, when Timing) float64 {
+	if rate == 0 {
+		return -(pv + pmt*nper)
+	}
+
+	f := math.Pow(1+rate, nper)
+	return -(pv*f + pmt*(1+rate*float64(when))*(f-1)/rate)
+}
+
+// PV is the present value of fv and nper payments of pmt
+func PV(rate, nper, pmt, fv float64, when Timing) float64 {
+	if rate == 0 {
+		return -(fv + pmt*nper)
+	}
+
+	f := math.Pow(1+rate, nper)
+	return -(fv + pmt*(1+rate*float64(when))*(f-1)/rate) / f
+}
+
+// PMT is the payment per period turning pv into fv in nper periods
+func PMT(rate, nper, pv, fv float64, when Timing) float64 {
+	if rate == 0 {
+		return -(fv + pv) / nper
+	}
+
+	f := math.Pow(1+rate, nper)
+	return -(fv + pv*f) * rate / ((1 + rate*float64(when)) * (f - 1))
+}
+
+// NPER is the number of periods turning pv into fv with payments of pmt
+func NPER(rate, pmt, pv, fv float64, when Timing) (float64, error) {
+	var n float64
+	if rate == 0 {
+		n = -(fv + pv) / pmt
+	} else {
+		z := pmt * (1 + rate*float64(when)) / rate
+		n = math.Log((z-fv)/(z+pv)) / math.Log(1+rate)
+	}
+
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("number of periods: %w", ErrNoSolution)
+	}
+	return n, nil
+}
+
+// Rate is the interest rate per period turning pv into fv in nper periods with payments of pmt.
+// payments adding up to the money need no interest, otherwise it tries Newton's method starting from 10%
+// and falls back to bisection when it doesn't converge
+func Rate(nper, pmt, pv, fv float64, when Timing) (float64, error) {
+	// residual is the future value left once every payment is made, which is 0 at the rate
+	residual := func(r float64) float64 {
+		return FV(r, nper, pmt, pv, when) - fv
+	}
+
+	if residual(0) == 0 {
+		return 0, nil
+	}
+	if r, err := newton(residual, 0.1); err == nil {
+		return r, nil
+	}
+	r, err := bisect(residual, -1+1e-9, 1e3)
+	if err != nil {
+		return 0, fmt.Errorf("rate: %w", err)
+	}
+	return r, nil
+}
+
+// NPV is the net present value of cash flows, the first flow being at period 0 and not discounted
+func NPV(rate float64, flows []float64) float64 {
+	var npv float64
+	for i, flow := range flows {
+		npv += flow / math.Pow(1+rate, float64(i))
+	}
+	return npv
+}
+
+// IRR is the rate making the net present value of cash flows 0. flows need an inflow and an outflow.
+// it tries Newton's method first and falls back to bisection when it doesn't converge
+func IRR(flows []float64) (float64, error) {
+	var in, out bool
+	for _, flow := range flows {
+		in = in || flow > 0
+		out = out || flow < 0
+	}
+	if !in || !out {
+		return 0, fmt.Errorf("internal rate of return: %w, the cash flows need both an inflow and an outflow", ErrNoSolution)
+	}
+
+	npv := func(r float64) float64 { return NPV(r, flows) }
+	if r, err := newton(npv, 0.1); err == nil {
+		return r, nil
+	}
+	r, err := bisect(npv, -1+1e-9, 1e3)
+	if err != nil {
+		return 0, fmt.Errorf("internal rate of return: %w", err)
+	}
+	return r, nil
+}
+
+// Amortize is the schedule paying principal off in nper equal payments at the end of each period.
+// the last payment absorbs the rounding so the balance ends at exactly 0
+func Amortize(rate float64, nper int, principal float64) ([]Payment, error) {
+	if nper < 1 {
+		return nil, fmt.Errorf("amortizing in %d periods: it has to be at least 1", nper)
+	}
+	if nper > MaxPeriods {
+		return nil, fmt.Errorf("amortizing in %d periods: the limit is %d", nper, MaxPeriods)
+	}
+
+	payment := -PMT(rate, float64(nper), principal, 0, End)
+	schedule := make([]Payment, 0, nper)
+	balance := principal
+	for period := 1; period <= nper; period++ {
+		p := Payment{Period: period, Payment: payment, Interest: balance * rate}
+		p.Principal = p.Payment - p.Interest
+		if period == nper {
+			p.Principal = balance
+			p.Payment = p.Principal + p.Interest
+		}
+		balance -= p.Principal
+		p.Balance = balance
+		schedule = append(schedule, p)
+	}
+	return schedule, nil
+}
+
+// newton finds a root of f from guess, the derivative is approximated by a central difference
+func newton(f func(float64) float64, guess float64) (float64, error) {
+	x := guess
+	for i := 0; i < maxIterations; i++ {
+		y := f(x)
+		h := 1e-6 * math.Max(1, math.Abs(x))
+		slope := (f(x+h) - f(x-h)) / (2 * h)
+		if slope == 0 || math.IsNaN(slope) || math.IsInf(slope, 0) {
+			return 0, ErrNoSolution
+		}
+
+		next := x - y/slope
+		if math.IsNaN(next) || next <= -1 {
+			return 0, ErrNoSolution
+		}
+		if math.Abs(next-x) <= tolerance*math.Max(1, math.Abs(next)) {
+			return next, nil
+		}
+		x = next
+	}
+	return 0, ErrNoSolution
+}
+
+// bisect finds a root of f between lo and hi, f has to change its sign in between
+func bisect(f func(float64) float64, lo, hi float64) (float64, error) {
+	flo := f(lo)
+	if math.Signbit(flo) == math.Signbit(f(hi)) {
+		return 0, ErrNoSolution
+	}
+
+	for i := 0; i < 2*maxIterations && hi-lo > tolerance; i++ {
+		mid := lo + (hi-lo)/2
+		fmid := f(mid)
+		if fmid == 0 {
+			return mid, nil
+		}
+		if math.Signbit(fmid) == math.Signbit(flo) {
+			lo, flo = mid, fmid
+		} else {
+			hi = mid
+		}
+	}
+	return lo + (hi-lo)/2, nil
+}
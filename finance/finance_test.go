@@ -0,0 +1,158 @@
+package finance
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// reference values are the ones of numpy-financial and spreadsheets for the same arguments
+
+func TestTimeValueOfMoney(t *testing.T) {
+	tests := []struct {
+		name  string
+		value func() (float64, error)
+		want  float64
+		delta float64
+	}{
+		{
+			name:  "compound monthly",
+			value: func() (float64, error) { return Compound(1000, 0.05, 12, 10) },
+			want:  1647.00949769028,
+			delta: 1e-8,
+		},
+		{
+			name:  "fv of monthly savings",
+			value: func() (float64, error) { return FV(0.05/12, 10*12, -100, -100, End), nil },
+			want:  15692.928894335748,
+			delta: 1e-8,
+		},
+		{
+			name:  "fv without interest",
+			value: func() (float64, error) { return FV(0, 10, -100, -100, End), nil },
+			want:  1100,
+			delta: 0,
+		},
+		{
+			name:  "fv paying at the beginning",
+			value: func() (float64, error) { return FV(0.1, 2, -100, 0, Begin), nil },
+			want:  231,
+			delta: 1e-9,
+		},
+		{
+			name:  "pv of monthly savings",
+			value: func() (float64, error) { return PV(0.05/12, 10*12, -100, 15692.93, End), nil },
+			want:  -100.00067131625819,
+			delta: 1e-8,
+		},
+		{
+			name:  "pmt of a mortgage",
+			value: func() (float64, error) { return PMT(0.075/12, 12*15, 200000, 0, End), nil },
+			want:  -1854.0247200054619,
+			delta: 1e-8,
+		},
+		{
+			name:  "pmt without interest",
+			value: func() (float64, error) { return PMT(0, 4, 1000, 0, End), nil },
+			want:  -250,
+			delta: 0,
+		},
+		{
+			name:  "nper of a loan",
+			value: func() (float64, error) { return NPER(0.07/12, -150, 8000, 0, End) },
+			want:  64.07334877066185,
+			delta: 1e-8,
+		},
+		{
+			name:  "rate of a deposit",
+			value: func() (float64, error) { return Rate(10, 0, -3500, 10000, End) },
+			want:  0.11069085371426901,
+			delta: 1e-10,
+		},
+		{
+			name:  "rate of a mortgage",
+			value: func() (float64, error) { return Rate(12*15, -1854.0247200054619, 200000, 0, End) },
+			want:  0.075 / 12,
+			delta: 1e-10,
+		},
+		{
+			name:  "rate of payments adding up to the loan",
+			value: func() (float64, error) { return Rate(10, -100, 1000, 0, End) },
+			want:  0,
+			delta: 1e-12,
+		},
+		{
+			name:  "rate newton overshoots",
+			value: func() (float64, error) { return Rate(2, -5000, 1000, 0, End) },
+			want:  4.854101966249685,
+			delta: 1e-9,
+		},
+		{
+			name:  "npv",
+			value: func() (float64, error) { return NPV(0.08, []float64{-40000, 5000, 8000, 12000, 30000}), nil },
+			want:  3065.2226681795255,
+			delta: 1e-8,
+		},
+		{
+			name:  "irr",
+			value: func() (float64, error) { return IRR([]float64{-100, 39, 59, 55, 20}) },
+			want:  0.28094842115996066,
+			delta: 1e-10,
+		},
+		{
+			name: "npv at the irr of a loss",
+			value: func() (float64, error) {
+				flows := []float64{-100, 20, 20, 20}
+				r, err := IRR(flows)
+				return NPV(r, flows), err
+			},
+			want:  0,
+			delta: 1e-9,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.value()
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, got, tt.delta)
+		})
+	}
+}
+
+func TestNoSolution(t *testing.T) {
+	_, err := IRR([]float64{100, 20, 30})
+	assert.True(t, errors.Is(err, ErrNoSolution))
+
+	_, err = NPER(0.01, 0, 1000, 0, End)
+	assert.True(t, errors.Is(err, ErrNoSolution))
+
+	_, err = Compound(1000, 0.05, 0, 10)
+	assert.Error(t, err)
+}
+
+func TestAmortize(t *testing.T) {
+	schedule, err := Amortize(0.01, 3, 1000)
+	assert.NoError(t, err)
+	assert.Len(t, schedule, 3)
+
+	assert.Equal(t, 1, schedule[0].Period)
+	assert.InDelta(t, 340.0221115, schedule[0].Payment, 1e-6)
+	assert.InDelta(t, 10, schedule[0].Interest, 1e-9)
+	assert.InDelta(t, 330.0221115, schedule[0].Principal, 1e-6)
+	assert.InDelta(t, 669.9778885, schedule[0].Balance, 1e-6)
+
+	var principal float64
+	for _, p := range schedule {
+		principal += p.Principal
+		assert.InDelta(t, p.Payment, p.Interest+p.Principal, 1e-9)
+	}
+	assert.InDelta(t, 1000, principal, 1e-9)
+	assert.Equal(t, float64(0), schedule[2].Balance)
+
+	_, err = Amortize(0.01, 0, 1000)
+	assert.Error(t, err)
+
+	_, err = Amortize(0.01, 1e9, 100)
+	assert.EqualError(t, err, "amortizing in 1000000000 periods: the limit is 100000")
+}
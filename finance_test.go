@@ -0,0 +1,106 @@
+package main
+
+import (
+	"testing"
+
+	"gitlab.com/atthoriq/calculator-project/calculator"
+)
+
+func Test_calculatorHandler_Handle_Finance(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "compound",
+			command: "compound 1000 5% 12 10",
+			want:    "1647.01",
+		},
+		{
+			name:    "fv",
+			command: "fv 0.05 10 -100",
+			want:    "1257.79",
+		},
+		{
+			name:    "fv paying at the beginning",
+			command: "fv 10% 2 -100 0 begin",
+			want:    "231.00",
+		},
+		{
+			name:    "pmt of a mortgage",
+			command: "pmt 0.625% 180 200000",
+			want:    "-1854.02",
+		},
+		{
+			name:    "nper",
+			command: "nper 1% -100 1000",
+			want:    "10.59",
+		},
+		{
+			name:    "npv",
+			command: "npv 8% -40000 5000 8000 12000 30000",
+			want:    "3065.22",
+		},
+		{
+			name:    "irr",
+			command: "irr -100 39 59 55 20",
+			want:    "0.28",
+		},
+		{
+			name:    "irr without inflow",
+			command: "irr -100 -20",
+			wantErr: true,
+		},
+		{
+			name:    "pv with too few arguments",
+			command: "pv 5% 10",
+			wantErr: true,
+		},
+		{
+			name:    "rate of payments adding up to the loan",
+			command: "rate 10 -100 1000",
+			want:    "0.00",
+		},
+		{
+			name:    "rate with invalid number",
+			command: "rate 10 0 -3500 1O000",
+			wantErr: true,
+		},
+		{
+			name:    "amortize",
+			command: "amortize 1% 3 1000",
+			want: "period  payment  interest  principal  balance\n" +
+				"     1   340.02     10.00     330.02   669.98\n" +
+				"     2   340.02      6.70     333.32   336.66\n" +
+				"     3   340.02      3.37     336.66     0.00\n" +
+				" total  1020.07     20.07    1000.00",
+		},
+		{
+			name:    "amortize in a fraction of period",
+			command: "amortize 1% 2.5 1000",
+			wantErr: true,
+		},
+		{
+			name:    "amortize in too many periods",
+			command: "amortize 0.01 1e9 100",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := InitCalculatorHandler(calculator.InitNewCalculator())
+			got, err := ch.Handle(tt.command)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("calculatorHandler.Handle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("calculatorHandler.Handle() = \n%v\nwant\n%v", got, tt.want)
+			}
+			if current := ch.calculator.GetResult(); current != 0 {
+				t.Errorf("current = %v, want it untouched", current)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+// numberFormat is the single place results are turned into text. the zero value prints 2 decimals,
+// which is the format the calculator always had.
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	formatFixed = "fixed"
+	formatSig   = "sig"
+	formatSci   = "sci"
+	formatEng   = "eng"
+	formatAuto  = "auto"
+
+	defaultDecimals = 2
+	// autoDigits is the number of significant digits shown by auto, enough to hide most rounding noise
+	autoDigits = 12
+	maxDigits  = 17
+)
+
+// siPrefixes are indexed by the engineering exponent divided by 3, offset by 8 (yocto)
+var siPrefixes = []string{"y", "z", "a", "f", "p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E", "Z", "Y"}
+
+type numberFormat struct {
+	style  string
+	digits int
+}
+
+// parseNumberFormat reads "<style> [digits]", e.g. "fixed 4", "sig 3" or "auto"
+func parseNumberFormat(args []string) (numberFormat, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return numberFormat{}, errInvalidInput
+	}
+
+	style := args[0]
+	switch style {
+	case formatAuto:
+		if len(args) > 1 {
+			return numberFormat{}, errInvalidInput
+		}
+		return numberFormat{style: style}, nil
+	case formatFixed, formatSig, formatSci, formatEng:
+	default:
+		return numberFormat{}, fmt.Errorf("unknown format %q: use fixed, sig, sci, eng or auto", style)
+	}
+
+	digits := defaultDecimals
+	if len(args) == 2 {
+		d, err := strconv.Atoi(args[1])
+		if err != nil || d < 0 || d > maxDigits || (style == formatSig && d == 0) {
+			return numberFormat{}, fmt.Errorf("invalid number of digits %q", args[1])
+		}
+		digits = d
+	}
+
+	return numberFormat{style: style, digits: digits}, nil
+}
+
+func (f numberFormat) String() string {
+	switch f.style {
+	case "":
+		return fmt.Sprintf("%s %d", formatFixed, defaultDecimals)
+	case formatAuto:
+		return formatAuto
+	default:
+		return fmt.Sprintf("%s %d", f.style, f.digits)
+	}
+}
+
+func (f numberFormat) format(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+
+	switch f.style {
+	case formatFixed:
+		return strconv.FormatFloat(v, 'f', f.digits, 64)
+	case formatSig:
+		return formatSignificant(v, f.digits)
+	case formatSci:
+		return strconv.FormatFloat(v, 'e', f.digits, 64)
+	case formatEng:
+		return formatEngineering(v, f.digits)
+	case formatAuto:
+		return strconv.FormatFloat(v, 'g', autoDigits, 64)
+	default:
+		return strconv.FormatFloat(v, 'f', defaultDecimals, 64)
+	}
+}
+
+// formatSignificant rounds v to n significant figures, falling back to scientific notation for very large or small values
+func formatSignificant(v float64, n int) string {
+	if v == 0 {
+		return strconv.FormatFloat(0, 'f', n-1, 64)
+	}
+
+	// let strconv do the rounding, then read the exponent of the rounded value
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'e', n-1, 64), 64)
+	exp := int(math.Floor(math.Log10(math.Abs(rounded))))
+	if exp >= 21 || exp < -6 {
+		return strconv.FormatFloat(v, 'e', n-1, 64)
+	}
+
+	decimals := n - 1 - exp
+	if decimals < 0 {
+		decimals = 0
+	}
+	return strconv.FormatFloat(rounded, 'f', decimals, 64)
+}
+
+// formatEngineering writes v with an exponent multiple of 3 shown as SI prefix, e.g. 12.35k
+func formatEngineering(v float64, decimals int) string {
+	if v == 0 {
+		return strconv.FormatFloat(0, 'f', decimals, 64)
+	}
+
+	exp := int(math.Floor(math.Log10(math.Abs(v))/3)) * 3
+	mantissa := v / math.Pow(10, float64(exp))
+	// rounding may carry the mantissa to 1000, e.g. 999.999 with 2 decimals
+	if s := strconv.FormatFloat(math.Abs(mantissa), 'f', decimals, 64); strings.HasPrefix(s, "1000") {
+		exp += 3
+		mantissa /= 1000
+	}
+
+	text := strconv.FormatFloat(mantissa, 'f', decimals, 64)
+	i := exp/3 + 8
+	if i < 0 || i >= len(siPrefixes) {
+		return fmt.Sprintf("%se%d", text, exp)
+	}
+	return text + siPrefixes[i]
+}
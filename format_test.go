@@ -0,0 +1,170 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_numberFormat_format(t *testing.T) {
+	tests := []struct {
+		name   string
+		format numberFormat
+		value  float64
+		want   string
+	}{
+		{
+			name:   "zero value - 2 decimals",
+			format: numberFormat{},
+			value:  0.001,
+			want:   "0.00",
+		},
+		{
+			name:   "fixed 4",
+			format: numberFormat{formatFixed, 4},
+			value:  0.001,
+			want:   "0.0010",
+		},
+		{
+			name:   "sig 3 of small number",
+			format: numberFormat{formatSig, 3},
+			value:  0.00123456,
+			want:   "0.00123",
+		},
+		{
+			name:   "sig 3 of large number",
+			format: numberFormat{formatSig, 3},
+			value:  123456,
+			want:   "123000",
+		},
+		{
+			name:   "sig 3 rounding up a digit",
+			format: numberFormat{formatSig, 3},
+			value:  9.996,
+			want:   "10.0",
+		},
+		{
+			name:   "sig 2 of huge number - scientific",
+			format: numberFormat{formatSig, 2},
+			value:  1e25,
+			want:   "1.0e+25",
+		},
+		{
+			name:   "sci 2",
+			format: numberFormat{formatSci, 2},
+			value:  1e20,
+			want:   "1.00e+20",
+		},
+		{
+			name:   "eng 2 with kilo",
+			format: numberFormat{formatEng, 2},
+			value:  12345,
+			want:   "12.35k",
+		},
+		{
+			name:   "eng 1 with micro",
+			format: numberFormat{formatEng, 1},
+			value:  -0.0000047,
+			want:   "-4.7µ",
+		},
+		{
+			name:   "eng 2 carrying to the next prefix",
+			format: numberFormat{formatEng, 2},
+			value:  999999,
+			want:   "1.00M",
+		},
+		{
+			name:   "eng beyond prefixes",
+			format: numberFormat{formatEng, 0},
+			value:  1e30,
+			want:   "1e30",
+		},
+		{
+			name:   "auto hides rounding noise",
+			format: numberFormat{style: formatAuto},
+			value:  0.1 + 0.2,
+			want:   "0.3",
+		},
+		{
+			name:   "auto with huge number",
+			format: numberFormat{style: formatAuto},
+			value:  1e20,
+			want:   "1e+20",
+		},
+		{
+			name:   "NaN",
+			format: numberFormat{formatSci, 2},
+			value:  math.NaN(),
+			want:   "NaN",
+		},
+		{
+			name:   "infinity",
+			format: numberFormat{formatEng, 2},
+			value:  math.Inf(-1),
+			want:   "-Inf",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format.format(tt.value); got != tt.want {
+				t.Errorf("numberFormat.format() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseNumberFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    numberFormat
+		wantErr bool
+	}{
+		{
+			name: "style with digits",
+			args: []string{"sig", "5"},
+			want: numberFormat{formatSig, 5},
+		},
+		{
+			name: "style without digits - default digits",
+			args: []string{"sci"},
+			want: numberFormat{formatSci, defaultDecimals},
+		},
+		{
+			name: "auto",
+			args: []string{"auto"},
+			want: numberFormat{style: formatAuto},
+		},
+		{
+			name:    "auto with digits",
+			args:    []string{"auto", "3"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown style",
+			args:    []string{"roman"},
+			wantErr: true,
+		},
+		{
+			name:    "zero significant figures",
+			args:    []string{"sig", "0"},
+			wantErr: true,
+		},
+		{
+			name:    "digits are not a number",
+			args:    []string{"fixed", "two"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNumberFormat(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseNumberFormat() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseNumberFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,31 +3,77 @@ package main
 import (
 	"errors"
 	"fmt"
-	"strconv"
+	"io"
+	"math"
+	"os"
 	"strings"
 
 	"gitlab.com/atthoriq/calculator-project/calculator"
+	"gitlab.com/atthoriq/calculator-project/chrono"
+	"gitlab.com/atthoriq/calculator-project/currency"
+	exporter "gitlab.com/atthoriq/calculator-project/export"
+	"gitlab.com/atthoriq/calculator-project/matrix"
+	"gitlab.com/atthoriq/calculator-project/programmer"
+	"gitlab.com/atthoriq/calculator-project/units"
 )
 
 const (
-	add      = "add"
-	subtract = "subtract"
-	multiply = "multiply"
-	divide   = "divide"
-	neg      = "neg"
-	abs      = "abs"
-	sqrt     = "sqrt"
-	cbrt     = "cbrt"
-	sqr      = "sqr"
-	cube     = "cube"
-	repeat   = "repeat"
-	cancel   = "cancel"
-	exit     = "exit"
-	help     = "help"
+	add       = "add"
+	subtract  = "subtract"
+	multiply  = "multiply"
+	divide    = "divide"
+	neg       = "neg"
+	abs       = "abs"
+	sqrt      = "sqrt"
+	cbrt      = "cbrt"
+	sqr       = "sqr"
+	cube      = "cube"
+	percentOf = "percentof"
+	pctChange = "pctchange"
+	markup    = "markup"
+	margin    = "margin"
+	discount  = "discount"
+	repeat    = "repeat"
+	cancel    = "cancel"
+	invert    = "invert"
+	formula   = "formula"
+	export    = "export"
+	importOp  = "import"
+	tapeOp    = "tape"
+	subtotal  = "subtotal"
+	formatOp  = "format"
+	localeOp  = "locale"
+	modeOp    = "mode"
+	bitsOp    = "bits"
+	ulp       = "ulp"
+	nextup    = "nextup"
+	nextdown  = "nextdown"
+	precision = "precision"
+
+	modeStandard   = "std"
+	modeProgrammer = "prog"
+	modeStats      = "stats"
+	modeTime       = "time"
+	modeSigfig     = "sigfig"
+	modeMatrix     = "matrix"
+	exit           = "exit"
+	help           = "help"
+
+	formulaText  = "text"
+	formulaLaTeX = "latex"
+
+	exportGo       = "go"
+	exportCSV      = "csv"
+	exportMarkdown = "md"
+	exportJSON     = "json"
 
 	manual = `calculator will calculate new value to the current value. initial value will be 0.
-add <float>      : add <float> to current
-subtract <float> : subtract <float> to current
+<float> can be written as 2.5, 1e3, 0x1F, 0b101, 0o17, 1/3, 1 1/2, 15%, 1_000_000, 3k or 2.5M
+and in degrees, minutes and seconds as 12°30'15", 12°30.5' or 45°S, or in hours as 1:30 or 1:30:15
+add, subtract, multiply and divide take a unit after <float>, e.g. add 5 km, multiply 2 h or divide 9.81 m/s^2
+and an uncertain <float> as <float>±<tolerance>, <float>+/-<tolerance> or 10±5%. results show their bounds
+add <float>      : add <float> to current. add 15% adds 15% of current
+subtract <float> : subtract <float> to current. subtract 15% subtracts 15% of current
 multiply <float> : add <float> to current
 divide <float>   : add <float> to current
 neg              : make current to negative. equally multiplying -1 to current. it requires no <float>
@@ -36,14 +82,143 @@ sqrt             : compute sqrt of current
 cbrt             : compute cbrt of current
 sqr              : compute sqr of current
 cube             : compute cube of current
+percentof <p>    : take <p> percent of current. <p> can be typed as 15 or 15%
+pctchange <x>    : change from current to <x> in percent of current
+markup <p>       : mark current cost up by <p> percent of the cost
+margin <p>       : price of current cost with <p> percent of the price as margin
+discount <p>     : reduce current by <p> percent
+gcd <n>, lcm <n>  : greatest common divisor and least common multiple of integer current and <n>
+isprime          : tell whether integer current is prime
+factor           : print the prime factorization of integer current
+nextprime        : smallest prime greater than integer current
+modpow <e> <m>   : current to the power of <e> modulo <m>
+modinv <m>       : inverse of current modulo <m>
+fact             : factorial of integer current. it fails when the result overflows float64
+gamma, lgamma    : gamma function of current and the natural logarithm of its absolute value
+ncr <k>, npr <k> : combinations and permutations of <k> out of integer current
+binom <k> <p>    : probability of exactly <k> successes out of current trials of probability <p>
+exact [on|off]   : show or set whether fact, ncr and npr print every digit, even beyond float64
+frac [tolerance] : show the simplest fraction within <tolerance> of current, 1e-6 by default, e.g. 1/3 for 0.333333
+frac <on|off>    : show the fraction a result is equal to next to it, e.g. 0.33 ≈ 1/3 after 1 divided by 3
+cf [terms]       : show the continued fraction of current, e.g. [3; 7, 15, 1, 292] for pi
+convert <unit>   : convert current to <unit>, e.g. mi, km/h or degF
+unit [unit|none] : show, set or clear the unit of current without changing its value
+currency [code]  : show, set or clear (none) the currency of current, e.g. USD. amounts are rounded to its minor unit
+convert <code>   : convert current to the currency <code> with the loaded rates
+rates [file]     : load the exchange rates of a json or csv file, or list them with their date
+minor <code> <n> : round amounts of <code> to <n> decimals instead of its minor unit
 repeat <float>   : repeating <float> steps behind
 cancel           : cancel calculation which set the current to 0.
+invert           : undo the whole history to recover the starting value. fails on abs, sqr, multiply or divide by 0 and cancel
+formula [latex]  : show the history as a formula of the starting value x, as plain text or latex
+export go <name> : print a go function <name> reproducing the history, followed by its test
+export csv <file>: write the history steps to <file> as csv. md and json are supported as well
+import <file>    : replay the steps of a json export on top of current
+tape <on|off>    : print every calculation as an adding-machine tape entry. cancel prints the total
+tape print [file]: print the whole tape, or write it to <file>
+subtotal         : show current, marked as subtotal on the tape
+display [name]   : show or set the notation of the results, dec, dms (12°30'15.00") or hms (1:30:15.00)
+bounds [style]   : show the bounds of current, or set how results show them, pm (10.00±0.50) or range ([9.50, 10.50])
+dms, hms, dec    : show current in degrees, minutes and seconds, in h:m:s or in decimal
+format [style]   : show or set the result format. styles are fixed <n>, sig <n>, sci <n>, eng <n> (SI prefixes) and auto
+locale [tag]     : show or set the separators of typed and printed numbers, e.g. en-US, de-DE, fr-FR, en-IN or C
+mode [name]      : show or switch the mode, std, prog, stats, time, sigfig or matrix. prog works on current as an integer shown in dec, hex, oct and bin
+figures          : tell the significant figures of current. in mode sigfig results are rounded to the figures of the typed operands, 2.50 has 3, 1200 has 2 and 1/3 is exact
+bits             : show the sign, exponent and mantissa bits of current
+ulp              : show the unit in the last place of current
+nextup, nextdown : show the next representable value above or below current
+precision [type] : show or set the precision of every operation, float32 or float64
+accuracy [on|off]: tell the rounding error accumulated by +, -, × and ÷, or switch on compensated sums and fused multiply-add
 exit             : exit the calculator
-help             : show the manual`
+help             : show the manual
+
+financial, printed without changing current. <rate> is per period, e.g. 0.05 or 5%:
+compound <principal> <rate> <per year> <years> : principal with the annual rate compounded <per year> times a year
+fv <rate> <nper> <pmt> [pv] [begin|end]        : future value of [pv] and <nper> payments of <pmt>
+pv <rate> <nper> <pmt> [fv] [begin|end]        : present value of [fv] and <nper> payments of <pmt>
+pmt <rate> <nper> <pv> [fv] [begin|end]        : payment per period turning <pv> into [fv]
+nper <rate> <pmt> <pv> [fv] [begin|end]        : number of periods turning <pv> into [fv]
+rate <nper> <pmt> <pv> [fv] [begin|end]        : interest rate per period turning <pv> into [fv]
+npv <rate> <cf0> <cf1> ...                     : net present value of cash flows, <cf0> is not discounted
+irr <cf0> <cf1> ...                            : internal rate of return of cash flows
+amortize <rate> <nper> <principal>             : print the schedule paying <principal> off in <nper> payments
+
+programmer mode (mode prog):
+add, subtract, multiply, divide <int> : integer arithmetic, division truncates toward zero
+and, or, xor <int>                    : bitwise operation with <int>
+not, neg                              : bitwise complement and two's complement negation
+shl, shr, rol, ror <n>                : shift or rotate by <n> bits. shr keeps the sign when signed
+width <8|16|32|64>                    : set the width of the integer
+signed, unsigned                      : read the bits as signed or unsigned
+overflow [wrap|error]                 : show or set whether overflowing arithmetic wraps or fails
+cancel                                : set the integer to 0
+
+stats mode (mode stats), other commands work as in std mode:
+<float> <float> ...           : push the numbers to the dataset
+mean, median, mode            : mean, median and most frequent values of the dataset
+stddev, variance [sample|pop] : sample (default) or population standard deviation and variance
+min, max, sum, count          : smallest and largest value, sum and number of values
+percentile <p>                : <p>-th percentile, interpolating between the closest values
+point <x> <y>                 : push the point (<x>, <y>) to the dataset
+linreg                        : slope, intercept and r² of the line fitted to the points
+polyfit <degree>              : coefficients c0, c1 ... of y = c0 + c1·x + c2·x² ... fitted to the points
+expfit                        : a and b of y = a·e^(b·x) fitted to the points
+predict <x>                   : y of <x> by the last fit, linreg by default
+clear-data                    : empty the dataset and its points
+
+time mode (mode time), current is an instant or a duration:
+<time> is a date 2026-10-18, a time 2026-10-18 14:30[:05], 2026-10-18T14:30:00+07:00, @<unix timestamp>, now or today
+<duration> is e.g. 1h30m, 3d, 1w2d or 90s. a day is 24 hours
+add, subtract <time|duration> : add or subtract, an instant minus an instant is the duration between them
+multiply, divide <float>      : scale a duration
+now                           : set current to the current time
+diff <time>                   : years, months, days and time from current to <time>
+workdays <time>               : business days from current to <time>, both included
+addworkdays <n>               : move current by <n> business days, skipping weekends and holidays
+holidays [file]               : load the holidays of a file with a date per line, or list them
+tz [zone]                     : show the time zone or show current in the zone of the tz database, e.g. Asia/Jakarta
+unix                          : show the unix timestamp of current
+cancel                        : set current to a zero duration
+
+matrix mode (mode matrix), current is a matrix, a vector or a scalar. a scalar goes back to current when leaving the mode:
+<matrix> is typed as [1 2; 3 4], rows separated by ;. a vector is a single row [1 2 3] or column [1; 2; 3]
+add, subtract <matrix|float>  : add or subtract element by element, a <float> to every element
+multiply <matrix|float>       : matrix product of current and <matrix>, or product by <float>
+divide <float>                : divide every element by <float>
+transpose                     : swap the rows and the columns of current
+det                           : determinant of current
+inverse                       : inverse of current
+solve <matrix>                : x such that current·x = <matrix>, e.g. solve [5; 6]
+dot, cross <vector>           : dot product and cross product of current and <vector>
+norm [1|2|inf|fro]            : norm of current, 2 by default. the 2-norm of a matrix is its largest singular value
+cancel                        : set current to 0`
 )
 
+var errInvalidInput = errors.New("invalid input: read manual with 'help' command")
+
 type calculatorHandler struct {
-	calculator calculator.NewCalculator
+	calculator   calculator.NewCalculator
+	tape         tape
+	numberFormat numberFormat
+	locale       locale
+	mode         string
+	register     *programmer.Register
+	clock        *chrono.Clock
+	// display is the notation of the results, dms or hms, decimal when empty
+	display string
+	// boundsStyle shows uncertain results as value±error (pm) or as range
+	boundsStyle string
+	// fit is the last fit of the points, used by predict
+	fit fitter
+	// exact prints factorials, combinations and permutations with every digit
+	exact bool
+	// fraction shows the simplest fraction close to the results next to them
+	fraction bool
+	// matrix is the running value of the matrix mode
+	matrix matrix.Matrix
+	// rates converts the currency of current and minors overrides its rounding
+	rates  *currency.Table
+	minors map[string]int
 }
 
 func InitCalculatorHandler(calc calculator.NewCalculator) *calculatorHandler {
@@ -55,82 +230,354 @@ func InitCalculatorHandler(calc calculator.NewCalculator) *calculatorHandler {
 // Handle is to handle command string given from user
 // to make no confusion, any commands requires only 1 argument will return error if they're given 2 or more
 func (ch *calculatorHandler) Handle(command string) (string, error) {
+	result, err := ch.handle(command)
+	if err != nil || !ch.tape.enabled || ch.mode == modeProgrammer || ch.mode == modeTime || ch.mode == modeMatrix {
+		return result, err
+	}
+
+	// with the tape on, calculations are printed as tape entries instead
+	op, args, _ := parseCommand(command)
+	if op == cancel {
+		entry, _ := ch.tape.record(op, "", 0, ch.format)
+		return entry, nil
+	}
+	if _, ok := tapeSymbols[op]; !ok {
+		return result, nil
+	}
+
+	entry, _ := ch.tape.record(op, ch.tapeOperand(op, args), ch.calculator.GetResult(), ch.format)
+	return entry, nil
+}
+
+// tapeOperand is the operand printed on the tape, in the result format when it's a value
+func (ch *calculatorHandler) tapeOperand(op string, args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	if v, err := ch.parseOperand(args); err == nil && op != repeat {
+		if v.percent {
+			return ch.format(v.value*100) + "%"
+		}
+		return ch.format(v.value)
+	}
+	return strings.Join(args, " ")
+}
+
+// format prints a result in the chosen format and locale
+func (ch *calculatorHandler) format(v float64) string {
+	if ch.display != "" {
+		return ch.formatSexagesimal(v, ch.display)
+	}
+	return ch.locale.localize(ch.numberFormat.format(v))
+}
+
+func (ch *calculatorHandler) handle(command string) (string, error) {
 	// sanitize leading and trailing white spaces
-	op, value, err := parseCommand(command)
+	op, args, err := parseCommand(command)
 	if err != nil {
 		return "", err
 	}
 
+	switch {
+	case ch.mode == modeStats && ch.isStats(op, args):
+		return ch.handleStats(op, args)
+	case op == modeOp:
+		return ch.handleMode(args)
+	case ch.mode == modeProgrammer && op != help && op != exit:
+		return ch.handleProgrammer(op, args)
+	case ch.mode == modeTime && op != help && op != exit:
+		return ch.handleTime(op, args)
+	case ch.mode == modeMatrix && op != help && op != exit && op != formatOp && op != localeOp:
+		// the format and the locale apply to the elements of the grid
+		return ch.handleMatrix(op, args)
+	case op == convert && ch.isCurrencyConversion(args):
+		return ch.handleCurrency(op, args)
+	}
+
 	switch op {
 	case add:
-		res := ch.calculator.Add(value).GetResult()
-		return fmt.Sprintf("%.2f", res), nil
+		value, unit, err := ch.parseQuantity(args)
+		if err != nil {
+			return "", err
+		}
+
+		// like a desk calculator, adding 15% adds 15% of current
+		if value.percent {
+			res := ch.calculator.AddPercent(value.value * 100).GetResult()
+			return ch.formatCurrent(res), nil
+		}
+
+		tolerance, _, err := ch.inCurrentUnit(value.tolerance, unit)
+		if err != nil {
+			return "", err
+		}
+
+		// the step records the value in the unit of current, the one it is added in
+		v, unit, err := ch.inCurrentUnit(value.value, unit)
+		if err != nil {
+			return "", err
+		}
+
+		var calc calculator.NewCalculator
+		if unit.IsNone() {
+			calc = ch.calculator.Add(v)
+		} else {
+			calc = ch.calculator.AddUnit(v, unit)
+		}
+
+		res := ch.significant(within(calc, v, tolerance), value, v).GetResult()
+		return ch.formatCurrent(res), nil
 	case subtract:
-		res := ch.calculator.Subtract(value).GetResult()
-		return fmt.Sprintf("%.2f", res), nil
+		value, unit, err := ch.parseQuantity(args)
+		if err != nil {
+			return "", err
+		}
+
+		if value.percent {
+			res := ch.calculator.SubtractPercent(value.value * 100).GetResult()
+			return ch.formatCurrent(res), nil
+		}
+
+		tolerance, _, err := ch.inCurrentUnit(value.tolerance, unit)
+		if err != nil {
+			return "", err
+		}
+
+		// the step records the value in the unit of current, the one it is added in
+		v, unit, err := ch.inCurrentUnit(value.value, unit)
+		if err != nil {
+			return "", err
+		}
+
+		var calc calculator.NewCalculator
+		if unit.IsNone() {
+			calc = ch.calculator.Subtract(v)
+		} else {
+			calc = ch.calculator.SubtractUnit(v, unit)
+		}
+
+		res := ch.significant(within(calc, v, tolerance), value, v).GetResult()
+		return ch.formatCurrent(res), nil
+	case percentOf, pctChange, markup, margin, discount:
+		value, err := ch.parseOperand(args)
+		if err != nil {
+			return "", err
+		}
+
+		// percentages can be typed with or without %, pctchange takes a plain value
+		p := value.value
+		if value.percent {
+			if op == pctChange {
+				return "", errInvalidInput
+			}
+			p *= 100
+		}
+
+		calc := map[string]func(float64) calculator.NewCalculator{
+			percentOf: ch.calculator.PercentOf,
+			pctChange: ch.calculator.PercentChange,
+			markup:    ch.calculator.Markup,
+			margin:    ch.calculator.Margin,
+			discount:  ch.calculator.Discount,
+		}[op](p)
+
+		res := calc.GetResult()
+		return ch.formatCurrent(res), nil
 	case multiply:
-		res := ch.calculator.Multiply(value).GetResult()
-		return fmt.Sprintf("%.2f", res), nil
+		value, unit, err := ch.parseQuantity(args)
+		if err != nil {
+			return "", err
+		}
+
+		var calc calculator.NewCalculator
+		if unit.IsNone() {
+			calc = ch.calculator.Multiply(value.value)
+		} else {
+			calc = ch.calculator.MultiplyUnit(value.value, unit)
+		}
+
+		res := ch.significant(within(calc, value.value, value.tolerance), value, value.value).GetResult()
+		return ch.formatCurrent(res), nil
 	case divide:
-		res := ch.calculator.Divide(value).GetResult()
-		return fmt.Sprintf("%.2f", res), nil
+		value, unit, err := ch.parseQuantity(args)
+		if err != nil {
+			return "", err
+		}
+
+		var calc calculator.NewCalculator
+		if unit.IsNone() {
+			calc = ch.calculator.Divide(value.value)
+		} else {
+			calc = ch.calculator.DivideUnit(value.value, unit)
+		}
+
+		res := ch.significant(within(calc, value.value, value.tolerance), value, value.value).GetResult()
+		return ch.formatCurrent(res), nil
 	case neg:
-		if value > 0 {
-			return "", errors.New("invalid input: read manual with 'help' command")
+		if len(args) > 0 {
+			return "", errInvalidInput
 		}
 
 		res := ch.calculator.Multiply(-1).GetResult()
-		return fmt.Sprintf("%.2f", res), nil
+		return ch.formatCurrent(res), nil
 	case abs:
-		if value > 0 {
-			return "", errors.New("invalid input: read manual with 'help' command")
+		if len(args) > 0 {
+			return "", errInvalidInput
 		}
 
 		res := ch.calculator.Abs().GetResult()
-		return fmt.Sprintf("%.2f", res), nil
+		return ch.formatCurrent(res), nil
 	case sqrt:
-		if value > 0 {
-			return "", errors.New("invalid input: read manual with 'help' command")
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+		if _, err := ch.calculator.GetUnit().Root(2); err != nil {
+			return "", err
 		}
 
 		res := ch.calculator.Root(2).GetResult()
-		return fmt.Sprintf("%.2f", res), nil
+		return ch.formatCurrent(res), nil
 	case cbrt:
-		if value > 0 {
-			return "", errors.New("invalid input: read manual with 'help' command")
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+		if _, err := ch.calculator.GetUnit().Root(3); err != nil {
+			return "", err
 		}
 
 		res := ch.calculator.Root(3).GetResult()
-		return fmt.Sprintf("%.2f", res), nil
+		return ch.formatCurrent(res), nil
 	case sqr:
-		if value > 0 {
-			return "", errors.New("invalid input: read manual with 'help' command")
+		if len(args) > 0 {
+			return "", errInvalidInput
 		}
 
 		res := ch.calculator.Pow(2).GetResult()
-		return fmt.Sprintf("%.2f", res), nil
+		return ch.formatCurrent(res), nil
 	case cube:
-		if value > 0 {
-			return "", errors.New("invalid input: read manual with 'help' command")
+		if len(args) > 0 {
+			return "", errInvalidInput
 		}
 
 		res := ch.calculator.Pow(3).GetResult()
-		return fmt.Sprintf("%.2f", res), nil
+		return ch.formatCurrent(res), nil
 	case repeat:
+		value, err := ch.parseValue(args)
+		if err != nil {
+			return "", err
+		}
+
 		res := ch.calculator.Repeat(int(value)).GetResult()
-		return fmt.Sprintf("%.2f", res), err
+		return ch.formatCurrent(res), nil
 	case cancel:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
 		res := ch.calculator.Cancel().GetResult()
-		return fmt.Sprintf("%.2f", res), nil
+		return ch.formatCurrent(res), nil
+	case invert:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
+		calc, err := ch.calculator.Invert()
+		if err != nil {
+			return "", err
+		}
+
+		res := calc.GetResult()
+		return ch.formatCurrent(res), nil
+	case formula:
+		return ch.handleFormula(args)
+	case export:
+		return ch.handleExport(args)
+	case importOp:
+		return ch.handleImport(args)
+	case tapeOp:
+		return ch.handleTape(args)
+	case formatOp:
+		if len(args) == 0 {
+			return ch.numberFormat.String(), nil
+		}
+
+		f, err := parseNumberFormat(args)
+		if err != nil {
+			return "", err
+		}
+
+		ch.numberFormat = f
+		return "format " + f.String(), nil
+	case convert, unitOp:
+		return ch.handleUnit(op, args)
+	case figuresOp:
+		return ch.handleFigures(args)
+	case accuracyOp:
+		return ch.handleAccuracy(args)
+	case boundsOp:
+		return ch.handleBounds(args)
+	case displayOp, dms, hms, dec:
+		return ch.handleSexagesimal(op, args)
+	case currencyOp, ratesOp, minorOp:
+		return ch.handleCurrency(op, args)
+	case gcd, lcm, isPrime, factor, nextPrime, modPow, modInv:
+		return ch.handleNumberTheory(op, args)
+	case fracOp, cfOp:
+		return ch.handleRational(op, args)
+	case factorial, gamma, lgamma, ncr, npr, binom, exactOp:
+		return ch.handleCombinatorics(op, args)
+	case compoundOp, fvOp, pvOp, pmtOp, nperOp, rateOp, npvOp, irrOp, amortizeOp:
+		return ch.handleFinance(op, args)
+	case bitsOp, ulp, nextup, nextdown:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
+		return ch.handleInspect(op), nil
+	case precision:
+		if len(args) == 0 {
+			return string(ch.calculator.GetPrecision()), nil
+		}
+		if len(args) > 1 {
+			return "", errInvalidInput
+		}
+
+		if err := ch.calculator.SetPrecision(calculator.Precision(args[0])); err != nil {
+			return "", err
+		}
+
+		return "precision " + args[0], nil
+	case localeOp:
+		if len(args) == 0 {
+			return ch.locale.String(), nil
+		}
+		if len(args) > 1 {
+			return "", errInvalidInput
+		}
+
+		loc, err := findLocale(args[0])
+		if err != nil {
+			return "", err
+		}
+
+		ch.locale = loc
+		return "locale " + loc.String(), nil
+	case subtotal:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
+		res := ch.calculator.GetResult()
+		return ch.formatCurrent(res), nil
 	case exit:
-		if value > 0 {
-			return "", errors.New("invalid input: read manual with 'help' command")
+		if len(args) > 0 {
+			return "", errInvalidInput
 		}
 
 		return "", nil
 	case help:
-		if value > 0 {
-			return "", errors.New("invalid input: read manual with 'help' command")
+		if len(args) > 0 {
+			return "", errInvalidInput
 		}
 
 		return manual, nil
@@ -139,23 +586,395 @@ func (ch *calculatorHandler) Handle(command string) (string, error) {
 	}
 }
 
-func parseCommand(command string) (op string, val float64, err error) {
-	command = strings.TrimSpace(command)
+// handleFormula renders the history as a formula of the starting value x, in plain text by default
+func (ch *calculatorHandler) handleFormula(args []string) (string, error) {
+	if len(args) > 1 {
+		return "", errInvalidInput
+	}
 
-	commands := strings.Fields(command)
-	if len(commands) > 2 || len(commands) == 0 {
-		return "", 0, errors.New("invalid input: read manual with 'help' command")
+	format := formulaText
+	if len(args) == 1 {
+		format = args[0]
+	}
+
+	switch format {
+	case formulaText:
+		return ch.calculator.GetFormula().String(), nil
+	case formulaLaTeX:
+		return ch.calculator.GetFormula().LaTeX(), nil
+	default:
+		return "", errInvalidInput
+	}
+}
+
+// handleExport prints the history in the requested format
+func (ch *calculatorHandler) handleExport(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errInvalidInput
+	}
+
+	switch args[0] {
+	case exportGo:
+		if len(args) != 2 {
+			return "", errInvalidInput
+		}
+		if ch.calculator.GetPrecision() != calculator.Float64 {
+			return "", errors.New("go export only reproduces float64 precision")
+		}
+		funcName := args[1]
+		steps := ch.calculator.GetHistory()
+		for _, step := range steps {
+			if step.Compensated {
+				return "", errors.New("go export doesn't reproduce the compensated sums of accuracy on")
+			}
+		}
+		want := ch.calculator.GetResult()
+		input := want
+		if len(steps) > 0 {
+			input = steps[0].Before
+		}
+
+		source, test, err := exporter.Go(funcName, steps, input, want)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("// file: %s.go\n%s\n// file: %s_test.go\n%s", strings.ToLower(funcName), source, strings.ToLower(funcName), test), nil
+	case exportCSV, exportMarkdown, exportJSON:
+		if len(args) != 2 {
+			return "", errInvalidInput
+		}
+
+		write := map[string]func(io.Writer, []calculator.Step) error{
+			exportCSV:      exporter.CSV,
+			exportMarkdown: exporter.Markdown,
+			exportJSON:     exporter.JSON,
+		}[args[0]]
+
+		steps := ch.calculator.GetHistory()
+		if err := writeFile(args[1], func(w io.Writer) error { return write(w, steps) }); err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%d steps written to %s", len(steps), args[1]), nil
+	default:
+		return "", errInvalidInput
+	}
+}
+
+// handleInspect shows how current is stored in the precision of the calculator
+func (ch *calculatorHandler) handleInspect(op string) string {
+	layout := layoutOf(ch.calculator.GetPrecision())
+	current := ch.calculator.GetResult()
+
+	switch op {
+	case ulp:
+		return exact(layout.ulp(current))
+	case nextup:
+		return exact(layout.nextUp(current))
+	case nextdown:
+		return exact(layout.nextDown(current))
+	default:
+		return layout.inspectBits(current)
+	}
+}
+
+// handleMode shows or switches the mode
+func (ch *calculatorHandler) handleMode(args []string) (string, error) {
+	if len(args) == 0 {
+		if ch.mode == "" {
+			return modeStandard, nil
+		}
+		return ch.mode, nil
+	}
+	if len(args) > 1 {
+		return "", errInvalidInput
+	}
+
+	switch args[0] {
+	case modeStandard, modeProgrammer, modeStats, modeTime, modeSigfig, modeMatrix:
+		ch.leaveMode()
+	}
+
+	switch args[0] {
+	case modeStandard:
+		ch.mode = ""
+		return "mode " + modeStandard, nil
+	case modeProgrammer:
+		if err := ch.enterProgrammer(); err != nil {
+			return "", err
+		}
+
+		ch.mode = modeProgrammer
+		return fmt.Sprintf("mode %s %s: %s", modeProgrammer, ch.register.Kind(), ch.register), nil
+	case modeStats:
+		ch.mode = modeStats
+		return fmt.Sprintf("mode %s: %d values", modeStats, len(ch.calculator.GetData())), nil
+	case modeTime:
+		ch.enterTime()
+
+		ch.mode = modeTime
+		return fmt.Sprintf("mode %s: %s", modeTime, ch.clock), nil
+	case modeSigfig:
+		ch.mode = modeSigfig
+		return "mode " + modeSigfig, nil
+	case modeMatrix:
+		ch.enterMatrix()
+
+		ch.mode = modeMatrix
+		return fmt.Sprintf("mode %s: %s", modeMatrix, ch.matrix.Shape()), nil
+	default:
+		return "", fmt.Errorf("unknown mode %q", args[0])
+	}
+}
+
+// leaveMode brings the value of the mode back to current, the integer of prog mode or the scalar of matrix mode
+func (ch *calculatorHandler) leaveMode() {
+	switch ch.mode {
+	case modeProgrammer:
+		ch.leaveProgrammer()
+	case modeMatrix:
+		ch.leaveMatrix()
+	}
+}
+
+// setCurrent brings current to v by adding the difference, so the history tells how it got there.
+// a current which isn't finite starts over from v
+func (ch *calculatorHandler) setCurrent(v float64) {
+	current := ch.calculator.GetResult()
+	switch {
+	case v == current:
+		return
+	case math.IsNaN(current) || math.IsInf(current, 0) || math.IsNaN(v) || math.IsInf(v, 0):
+		ch.calculator.Cancel().Add(v).GetResult()
+	default:
+		ch.calculator.Add(v - current).GetResult()
+	}
+}
+
+// handleTape switches the tape on or off and prints it
+func (ch *calculatorHandler) handleTape(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errInvalidInput
+	}
+
+	switch args[0] {
+	case tapeOn, tapeOff:
+		if len(args) > 1 {
+			return "", errInvalidInput
+		}
+
+		if args[0] == tapeOn && !ch.tape.enabled {
+			ch.tape.total = ch.calculator.GetResult()
+		}
+		ch.tape.enabled = args[0] == tapeOn
+		return "tape " + args[0], nil
+	case tapePrint:
+		switch len(args) {
+		case 1:
+			if len(ch.tape.entries) == 0 {
+				return "tape is empty", nil
+			}
+
+			var sb strings.Builder
+			if err := ch.tape.print(&sb); err != nil {
+				return "", err
+			}
+			return strings.TrimSuffix(sb.String(), "\n"), nil
+		case 2:
+			if err := writeFile(args[1], ch.tape.print); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d tape entries written to %s", len(ch.tape.entries), args[1]), nil
+		}
+	}
+
+	return "", errInvalidInput
+}
+
+// handleImport replays the steps of a json export
+func (ch *calculatorHandler) handleImport(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errInvalidInput
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return "", err
 	}
+	defer f.Close()
 
-	op = commands[0]
-	if len(commands) == 2 {
-		valueInStr := commands[1]
-		v, err := strconv.ParseFloat(valueInStr, 64)
+	steps, err := exporter.ReadJSON(f)
+	if err != nil {
+		return "", err
+	}
+
+	for i, step := range steps {
+		if err := ch.replay(step); err != nil {
+			return "", fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+
+	res := ch.calculator.GetResult()
+	return ch.formatCurrent(res), nil
+}
+
+// replayer queues a recorded step taking arity arguments on the calculator
+type replayer struct {
+	arity int
+	queue func(c calculator.NewCalculator, args []float64)
+}
+
+var replayers = map[string]replayer{
+	calculator.OpAdd:      {1, func(c calculator.NewCalculator, args []float64) { c.Add(args[0]) }},
+	calculator.OpSubtract: {1, func(c calculator.NewCalculator, args []float64) { c.Subtract(args[0]) }},
+	calculator.OpMultiply: {1, func(c calculator.NewCalculator, args []float64) { c.Multiply(args[0]) }},
+	calculator.OpDivide:   {1, func(c calculator.NewCalculator, args []float64) { c.Divide(args[0]) }},
+	calculator.OpAbs:      {0, func(c calculator.NewCalculator, args []float64) { c.Abs() }},
+	calculator.OpRoot:     {1, func(c calculator.NewCalculator, args []float64) { c.Root(int(args[0])) }},
+	calculator.OpPow:      {1, func(c calculator.NewCalculator, args []float64) { c.Pow(args[0]) }},
+
+	calculator.OpAddPercent:      {1, func(c calculator.NewCalculator, args []float64) { c.AddPercent(args[0]) }},
+	calculator.OpSubtractPercent: {1, func(c calculator.NewCalculator, args []float64) { c.SubtractPercent(args[0]) }},
+	calculator.OpPercentOf:       {1, func(c calculator.NewCalculator, args []float64) { c.PercentOf(args[0]) }},
+	calculator.OpPercentChange:   {1, func(c calculator.NewCalculator, args []float64) { c.PercentChange(args[0]) }},
+	calculator.OpMarkup:          {1, func(c calculator.NewCalculator, args []float64) { c.Markup(args[0]) }},
+	calculator.OpMargin:          {1, func(c calculator.NewCalculator, args []float64) { c.Margin(args[0]) }},
+	calculator.OpDiscount:        {1, func(c calculator.NewCalculator, args []float64) { c.Discount(args[0]) }},
+
+	calculator.OpGCD:       {1, func(c calculator.NewCalculator, args []float64) { c.GCD(args[0]) }},
+	calculator.OpLCM:       {1, func(c calculator.NewCalculator, args []float64) { c.LCM(args[0]) }},
+	calculator.OpNextPrime: {0, func(c calculator.NewCalculator, args []float64) { c.NextPrime() }},
+	calculator.OpModPow:    {2, func(c calculator.NewCalculator, args []float64) { c.ModPow(args[0], args[1]) }},
+	calculator.OpModInv:    {1, func(c calculator.NewCalculator, args []float64) { c.ModInv(args[0]) }},
+
+	calculator.OpFactorial:    {0, func(c calculator.NewCalculator, args []float64) { c.Factorial() }},
+	calculator.OpGamma:        {0, func(c calculator.NewCalculator, args []float64) { c.Gamma() }},
+	calculator.OpLogGamma:     {0, func(c calculator.NewCalculator, args []float64) { c.LogGamma() }},
+	calculator.OpChoose:       {1, func(c calculator.NewCalculator, args []float64) { c.Choose(args[0]) }},
+	calculator.OpPermutations: {1, func(c calculator.NewCalculator, args []float64) { c.Permutations(args[0]) }},
+	calculator.OpBinomial:     {2, func(c calculator.NewCalculator, args []float64) { c.Binomial(args[0], args[1]) }},
+}
+
+// unitReplayer queues a recorded step of a quantity taking arity arguments on the calculator.
+// added quantities and conversions are checked against the unit of current the way typed ones are
+type unitReplayer struct {
+	arity int
+	queue func(ch *calculatorHandler, args []float64, u units.Unit) error
+}
+
+var unitReplayers = map[string]unitReplayer{
+	calculator.OpAdd: {1, func(ch *calculatorHandler, args []float64, u units.Unit) error {
+		v, u, err := ch.inCurrentUnit(args[0], u)
 		if err != nil {
-			return "", 0, errors.New("invalid input: read manual with 'help' command")
+			return err
+		}
+		ch.calculator.AddUnit(v, u)
+		return nil
+	}},
+	calculator.OpSubtract: {1, func(ch *calculatorHandler, args []float64, u units.Unit) error {
+		v, u, err := ch.inCurrentUnit(args[0], u)
+		if err != nil {
+			return err
+		}
+		ch.calculator.SubtractUnit(v, u)
+		return nil
+	}},
+	calculator.OpMultiply: {1, func(ch *calculatorHandler, args []float64, u units.Unit) error {
+		ch.calculator.MultiplyUnit(args[0], u)
+		return nil
+	}},
+	calculator.OpDivide: {1, func(ch *calculatorHandler, args []float64, u units.Unit) error {
+		ch.calculator.DivideUnit(args[0], u)
+		return nil
+	}},
+	calculator.OpConvert: {2, func(ch *calculatorHandler, args []float64, u units.Unit) error {
+		// the recorded factors convert from the unit current had then, they're computed again from the one it has now
+		_, err := ch.convertUnit(u)
+		return err
+	}},
+}
+
+// replay queues the step on the calculator, with its unit or its currency when it has one
+func (ch *calculatorHandler) replay(step calculator.Step) error {
+	if step.Op == calculator.OpExchange {
+		if step.Currency == "" || len(step.Args) != 1 {
+			return fmt.Errorf("operation %q expects 1 argument and a currency", step.Op)
 		}
-		val = v
+
+		ch.calculator.Exchange(step.Args[0], step.Currency)
+		return nil
+	}
+	if !step.Unit.IsNone() {
+		r, ok := unitReplayers[step.Op]
+		if !ok {
+			return fmt.Errorf("operation %q takes no unit", step.Op)
+		}
+		if len(step.Args) != r.arity {
+			return fmt.Errorf("operation %q expects %d arguments, got %d", step.Op, r.arity, len(step.Args))
+		}
+
+		return r.queue(ch, step.Args, step.Unit)
+	}
+
+	r, ok := replayers[step.Op]
+	if !ok {
+		return fmt.Errorf("not supported operation %q", step.Op)
+	}
+	if len(step.Args) != r.arity {
+		return fmt.Errorf("operation %q expects %d arguments, got %d", step.Op, r.arity, len(step.Args))
+	}
+
+	r.queue(ch.calculator, step.Args)
+	return nil
+}
+
+// writeFile creates the file and closes it after write is done
+func writeFile(name string, write func(w io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// parseCommand splits the command into the operation and its arguments
+func parseCommand(command string) (op string, args []string, err error) {
+	command = strings.TrimSpace(command)
+
+	commands := strings.Fields(command)
+	if len(commands) == 0 {
+		return "", nil, errInvalidInput
+	}
+
+	return commands[0], commands[1:], nil
+}
+
+// parseOperand reads the numeric argument of a command keeping whether it was a percentage
+func (ch *calculatorHandler) parseOperand(args []string) (operand, error) {
+	if len(args) == 0 {
+		return operand{}, errInvalidInput
+	}
+
+	return ch.locale.parseOperand(args)
+}
+
+// parseValue reads the numeric argument of a command. a command without argument has value 0
+func (ch *calculatorHandler) parseValue(args []string) (float64, error) {
+	if len(args) == 0 {
+		return 0, nil
+	}
+
+	op, err := ch.locale.parseOperand(args)
+	if err != nil {
+		return 0, err
 	}
 
-	return op, val, nil
+	return op.value, nil
 }
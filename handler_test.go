@@ -1,11 +1,17 @@
 package main
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"gitlab.com/atthoriq/calculator-project/calculator"
+	"gitlab.com/atthoriq/calculator-project/interval"
 	mock_main "gitlab.com/atthoriq/calculator-project/mock"
+	"gitlab.com/atthoriq/calculator-project/units"
 )
 
 func Test_calculatorHandler_Handle_Negative_Cases(t *testing.T) {
@@ -78,6 +84,17 @@ func Test_calculatorHandler_Handle_Negative_Cases(t *testing.T) {
 			want:    "",
 			wantErr: true,
 		},
+		{
+			name: "format is not supported",
+			fields: fields{
+				calculator: mock_main.NewMockNewCalculator(ctrl),
+			},
+			args: args{
+				command: "format roman",
+			},
+			want:    "",
+			wantErr: true,
+		},
 		{
 			name: "command requires 1 arg but given 2",
 			fields: fields{
@@ -146,6 +163,203 @@ func Test_calculatorHandler_Handle_Command_Cases(t *testing.T) {
 				mockCalc.EXPECT().GetResult().Return(float64(2))
 			},
 		},
+		{
+			name: "add percent command",
+			args: args{
+				command: "add 15%",
+			},
+			want:    "230.00",
+			wantErr: false,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {
+				mockCalc.EXPECT().AddPercent(float64(15)).Return(mockCalc)
+				mockCalc.EXPECT().GetResult().Return(float64(230))
+			},
+		},
+		{
+			name: "subtract percent command",
+			args: args{
+				command: "subtract 15%",
+			},
+			want:    "170.00",
+			wantErr: false,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {
+				mockCalc.EXPECT().SubtractPercent(float64(15)).Return(mockCalc)
+				mockCalc.EXPECT().GetResult().Return(float64(170))
+			},
+		},
+		{
+			name: "markup command with percentage",
+			args: args{
+				command: "markup 25%",
+			},
+			want:    "100.00",
+			wantErr: false,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {
+				mockCalc.EXPECT().Markup(float64(25)).Return(mockCalc)
+				mockCalc.EXPECT().GetResult().Return(float64(100))
+			},
+		},
+		{
+			name: "discount command with plain number",
+			args: args{
+				command: "discount 25",
+			},
+			want:    "60.00",
+			wantErr: false,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {
+				mockCalc.EXPECT().Discount(float64(25)).Return(mockCalc)
+				mockCalc.EXPECT().GetResult().Return(float64(60))
+			},
+		},
+		{
+			name: "pctchange command",
+			args: args{
+				command: "pctchange 230",
+			},
+			want:    "15.00",
+			wantErr: false,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {
+				mockCalc.EXPECT().PercentChange(float64(230)).Return(mockCalc)
+				mockCalc.EXPECT().GetResult().Return(float64(15))
+			},
+		},
+		{
+			name: "pctchange command with percentage",
+			args: args{
+				command: "pctchange 15%",
+			},
+			want:        "",
+			wantErr:     true,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {},
+		},
+		{
+			name: "margin command without value",
+			args: args{
+				command: "margin",
+			},
+			want:        "",
+			wantErr:     true,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {},
+		},
+		{
+			name: "formula command",
+			args: args{
+				command: "formula",
+			},
+			want:    "x * 2",
+			wantErr: false,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {
+				mockCalc.EXPECT().GetFormula().Return(calculator.InitNewCalculator().Multiply(2).GetFormula())
+			},
+		},
+		{
+			name: "formula command in latex",
+			args: args{
+				command: "formula latex",
+			},
+			want:    `\frac{x}{2}`,
+			wantErr: false,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {
+				mockCalc.EXPECT().GetFormula().Return(calculator.InitNewCalculator().Divide(2).GetFormula())
+			},
+		},
+		{
+			name: "formula command with unknown format",
+			args: args{
+				command: "formula pdf",
+			},
+			want:        "",
+			wantErr:     true,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {},
+		},
+		{
+			name: "invert command",
+			args: args{
+				command: "invert",
+			},
+			want:    "0.00",
+			wantErr: false,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {
+				mockCalc.EXPECT().Invert().Return(mockCalc, nil)
+				mockCalc.EXPECT().GetResult().Return(float64(0))
+			},
+		},
+		{
+			name: "invert command on non-invertible history",
+			args: args{
+				command: "invert",
+			},
+			want:    "",
+			wantErr: true,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {
+				mockCalc.EXPECT().Invert().Return(mockCalc, errors.New("abs is not invertible"))
+			},
+		},
+		{
+			name: "export go command",
+			args: args{
+				command: "export go triple",
+			},
+			want:    "// file: triple.go\npackage main\n\n// triple reproduces the operations recorded by the calculator.\n// multiplications are converted explicitly to float64 so they are never fused into an FMA,\n// which keeps the result identical to the calculator.\nfunc triple(x float64) float64 {\n\tx = float64(x * 3)\n\treturn x\n}\n\n// file: triple_test.go\npackage main\n\nimport (\n\t\"math\"\n\t\"testing\"\n)\n\nfunc TestTriple(t *testing.T) {\n\tgot := triple(2)\n\twant := float64(6)\n\tif got != want && !(math.IsNaN(got) && math.IsNaN(want)) {\n\t\tt.Errorf(\"triple() = %v, want %v\", got, want)\n\t}\n}\n",
+			wantErr: false,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {
+				mockCalc.EXPECT().GetPrecision().Return(calculator.Float64)
+				mockCalc.EXPECT().GetHistory().Return([]calculator.Step{{Op: calculator.OpMultiply, Args: []float64{3}, Before: 2, After: 6}})
+				mockCalc.EXPECT().GetResult().Return(float64(6))
+			},
+		},
+		{
+			name: "export command with unknown format",
+			args: args{
+				command: "export pdf triple",
+			},
+			want:        "",
+			wantErr:     true,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {},
+		},
+		{
+			name: "format command",
+			args: args{
+				command: "format eng 1",
+			},
+			want:        "format eng 1",
+			wantErr:     false,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {},
+		},
+		{
+			name: "export go command in float32 precision",
+			args: args{
+				command: "export go triple",
+			},
+			want:    "",
+			wantErr: true,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {
+				mockCalc.EXPECT().GetPrecision().Return(calculator.Float32)
+			},
+		},
+		{
+			name: "precision command",
+			args: args{
+				command: "precision float32",
+			},
+			want:    "precision float32",
+			wantErr: false,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {
+				mockCalc.EXPECT().SetPrecision(calculator.Float32).Return(nil)
+			},
+		},
+		{
+			name: "ulp command",
+			args: args{
+				command: "ulp",
+			},
+			want:    "2.220446049250313e-16",
+			wantErr: false,
+			expectation: func(mockCalc *mock_main.MockNewCalculator) {
+				mockCalc.EXPECT().GetPrecision().Return(calculator.Float64)
+				mockCalc.EXPECT().GetResult().Return(float64(1))
+			},
+		},
 		{
 			name: "exit command",
 			args: args{
@@ -162,6 +376,10 @@ func Test_calculatorHandler_Handle_Command_Cases(t *testing.T) {
 				calculator: mockCalc,
 			}
 			tt.expectation(mockCalc)
+			// the results are plain numbers
+			mockCalc.EXPECT().GetUnit().Return(units.None).AnyTimes()
+			mockCalc.EXPECT().GetCurrency().Return("").AnyTimes()
+			mockCalc.EXPECT().GetBounds().Return(interval.Point(0)).AnyTimes()
 			got, err := ch.Handle(tt.args.command)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("calculatorHandler.Handle() error = %v, wantErr %v", err, tt.wantErr)
@@ -173,3 +391,109 @@ func Test_calculatorHandler_Handle_Command_Cases(t *testing.T) {
 		})
 	}
 }
+
+func Test_calculatorHandler_Handle_Export_Import(t *testing.T) {
+	dir := t.TempDir()
+	ch := InitCalculatorHandler(calculator.InitNewCalculator())
+	for _, command := range []string{"add 5", "multiply 3", "sqrt", "divide 2"} {
+		if _, err := ch.Handle(command); err != nil {
+			t.Fatalf("calculatorHandler.Handle(%q) error = %v", command, err)
+		}
+	}
+
+	for _, format := range []string{"csv", "md", "json"} {
+		file := filepath.Join(dir, "history."+format)
+		got, err := ch.Handle("export " + format + " " + file)
+		if err != nil {
+			t.Fatalf("calculatorHandler.Handle() error = %v", err)
+		}
+		assert.Equal(t, "4 steps written to "+file, got)
+		assert.FileExists(t, file)
+	}
+
+	imported := InitCalculatorHandler(calculator.InitNewCalculator())
+	got, err := imported.Handle("import " + filepath.Join(dir, "history.json"))
+	if err != nil {
+		t.Fatalf("calculatorHandler.Handle() error = %v", err)
+	}
+	assert.Equal(t, "1.94", got)
+	assert.Equal(t, "sqrt((x + 5) * 3) / 2", imported.calculator.GetFormula().String())
+
+	_, err = imported.Handle("import " + filepath.Join(dir, "history.csv"))
+	assert.Error(t, err)
+}
+
+func Test_calculatorHandler_Handle_Export_Import_Units(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "history.json")
+	ch := InitCalculatorHandler(calculator.InitNewCalculator())
+	for _, command := range []string{"add 5 km", "divide 2 h", "convert m/s", "export json " + file} {
+		if _, err := ch.Handle(command); err != nil {
+			t.Fatalf("calculatorHandler.Handle(%q) error = %v", command, err)
+		}
+	}
+
+	imported := InitCalculatorHandler(calculator.InitNewCalculator())
+	got, err := imported.Handle("import " + file)
+	if err != nil {
+		t.Fatalf("calculatorHandler.Handle() error = %v", err)
+	}
+	assert.Equal(t, "0.69 m/s", got)
+
+	converted := filepath.Join(t.TempDir(), "converted.json")
+	for _, command := range []string{"cancel", "add 5 km", "convert mi", "export json " + converted} {
+		if _, err := ch.Handle(command); err != nil {
+			t.Fatalf("calculatorHandler.Handle(%q) error = %v", command, err)
+		}
+	}
+
+	miles := InitCalculatorHandler(calculator.InitNewCalculator())
+	if _, err := miles.Handle("add 1 mi"); err != nil {
+		t.Fatal(err)
+	}
+	got, err = miles.Handle("import " + converted)
+	if err != nil {
+		t.Fatalf("calculatorHandler.Handle() error = %v", err)
+	}
+	assert.Equal(t, "4.11 mi", got)
+
+	mass := InitCalculatorHandler(calculator.InitNewCalculator())
+	if _, err := mass.Handle("add 1 kg"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = mass.Handle("import " + converted)
+	assert.EqualError(t, err, "step 1: km (length) doesn't match kg (mass)")
+}
+
+func Test_calculatorHandler_Handle_Export_Mixed_Units(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "history.csv")
+	ch := InitCalculatorHandler(calculator.InitNewCalculator())
+	for _, command := range []string{"add 5 km", "add 300 m", "export csv " + file} {
+		if _, err := ch.Handle(command); err != nil {
+			t.Fatalf("calculatorHandler.Handle(%q) error = %v", command, err)
+		}
+	}
+
+	report, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, string(report), "\n1,add,5 km,0,5,")
+	assert.Contains(t, string(report), "\n2,add,0.3 km,5,5.3,")
+}
+
+// handleAll runs the commands on a new handler, stopping at the first error, and returns the last output
+func handleAll(t *testing.T, commands []string) (string, error) {
+	t.Helper()
+	return handleEach(InitCalculatorHandler(calculator.InitNewCalculator()), commands)
+}
+
+// handleEach runs the commands on ch, stopping at the first error, and returns the last output
+func handleEach(ch *calculatorHandler, commands []string) (got string, err error) {
+	for _, command := range commands {
+		got, err = ch.Handle(command)
+		if err != nil {
+			break
+		}
+	}
+	return got, err
+}
@@ -0,0 +1,128 @@
+package main
+
+// IEEE-754 inspector. it shows how current is stored: the sign, exponent and mantissa bits,
+// the unit in the last place and the neighbouring representable values, in float32 or float64.
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gitlab.com/atthoriq/calculator-project/calculator"
+)
+
+// floatLayout describes the bits of a binary floating point type
+type floatLayout struct {
+	name         string
+	exponentBits int
+	mantissaBits int
+}
+
+var (
+	float64Layout = floatLayout{"float64", 11, 52}
+	float32Layout = floatLayout{"float32", 8, 23}
+)
+
+func layoutOf(p calculator.Precision) floatLayout {
+	if p == calculator.Float32 {
+		return float32Layout
+	}
+	return float64Layout
+}
+
+func (l floatLayout) bias() int {
+	return 1<<(l.exponentBits-1) - 1
+}
+
+// bitsOf returns the raw bits of v in the layout
+func (l floatLayout) bitsOf(v float64) uint64 {
+	if l == float32Layout {
+		return uint64(math.Float32bits(float32(v)))
+	}
+	return math.Float64bits(v)
+}
+
+// inspectBits describes the sign, exponent and mantissa of v
+func (l floatLayout) inspectBits(v float64) string {
+	raw := l.bitsOf(v)
+	sign := raw >> (l.exponentBits + l.mantissaBits)
+	exponent := int(raw >> l.mantissaBits & (1<<l.exponentBits - 1))
+	mantissa := raw & (1<<l.mantissaBits - 1)
+
+	signText := "+"
+	if sign == 1 {
+		signText = "-"
+	}
+
+	var exponentText, mantissaText string
+	switch exponent {
+	case 1<<l.exponentBits - 1:
+		exponentText = "all ones"
+		if mantissa == 0 {
+			mantissaText = "infinity"
+		} else {
+			mantissaText = "NaN"
+		}
+	case 0:
+		exponentText = fmt.Sprintf("biased 0, subnormal 2^%d", 1-l.bias())
+		mantissaText = fmt.Sprintf("0.%s", trimmedFraction(mantissa, l.mantissaBits))
+	default:
+		exponentText = fmt.Sprintf("biased %d, unbiased %d", exponent, exponent-l.bias())
+		mantissaText = fmt.Sprintf("1.%s", trimmedFraction(mantissa, l.mantissaBits))
+	}
+
+	hexDigits := (1 + l.exponentBits + l.mantissaBits) / 4
+	return strings.Join([]string{
+		fmt.Sprintf("%s %s", l.name, strconv.FormatFloat(v, 'g', -1, 64)),
+		fmt.Sprintf("sign     %d (%s)", sign, signText),
+		fmt.Sprintf("exponent %0*b (%s)", l.exponentBits, exponent, exponentText),
+		fmt.Sprintf("mantissa %0*b (%s)", l.mantissaBits, mantissa, mantissaText),
+		fmt.Sprintf("hex      0x%0*X", hexDigits, raw),
+	}, "\n")
+}
+
+// trimmedFraction prints the mantissa bits after the binary point without trailing zeros
+func trimmedFraction(mantissa uint64, bits int) string {
+	s := strings.TrimRight(fmt.Sprintf("%0*b", bits, mantissa), "0")
+	if s == "" {
+		return "0"
+	}
+	return s
+}
+
+// nextUp returns the smallest representable value above v
+func (l floatLayout) nextUp(v float64) float64 {
+	if l == float32Layout {
+		return float64(math.Nextafter32(float32(v), float32(math.Inf(1))))
+	}
+	return math.Nextafter(v, math.Inf(1))
+}
+
+// nextDown returns the largest representable value below v
+func (l floatLayout) nextDown(v float64) float64 {
+	if l == float32Layout {
+		return float64(math.Nextafter32(float32(v), float32(math.Inf(-1))))
+	}
+	return math.Nextafter(v, math.Inf(-1))
+}
+
+// ulp returns the unit in the last place of v, the gap to the next value away from zero
+func (l floatLayout) ulp(v float64) float64 {
+	v = math.Abs(v)
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return math.NaN()
+	}
+
+	next := l.nextUp(v)
+	if math.IsInf(next, 1) {
+		// the largest value, its ulp is the gap below it
+		return v - l.nextDown(v)
+	}
+	return next - v
+}
+
+// exact prints v with every digit needed to read it back
+func exact(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_floatLayout_inspectBits(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout floatLayout
+		value  float64
+		want   string
+	}{
+		{
+			name:   "float64 normal",
+			layout: float64Layout,
+			value:  -3,
+			want: "float64 -3\n" +
+				"sign     1 (-)\n" +
+				"exponent 10000000000 (biased 1024, unbiased 1)\n" +
+				"mantissa 1000000000000000000000000000000000000000000000000000 (1.1)\n" +
+				"hex      0xC008000000000000",
+		},
+		{
+			name:   "float32 rounds the value",
+			layout: float32Layout,
+			value:  0.1,
+			want: "float32 0.1\n" +
+				"sign     0 (+)\n" +
+				"exponent 01111011 (biased 123, unbiased -4)\n" +
+				"mantissa 10011001100110011001101 (1.10011001100110011001101)\n" +
+				"hex      0x3DCCCCCD",
+		},
+		{
+			name:   "float64 subnormal",
+			layout: float64Layout,
+			value:  math.SmallestNonzeroFloat64,
+			want: "float64 5e-324\n" +
+				"sign     0 (+)\n" +
+				"exponent 00000000000 (biased 0, subnormal 2^-1022)\n" +
+				"mantissa 0000000000000000000000000000000000000000000000000001 (0.0000000000000000000000000000000000000000000000000001)\n" +
+				"hex      0x0000000000000001",
+		},
+		{
+			name:   "float32 infinity",
+			layout: float32Layout,
+			value:  math.Inf(1),
+			want: "float32 +Inf\n" +
+				"sign     0 (+)\n" +
+				"exponent 11111111 (all ones)\n" +
+				"mantissa 00000000000000000000000 (infinity)\n" +
+				"hex      0x7F800000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.layout.inspectBits(tt.value); got != tt.want {
+				t.Errorf("floatLayout.inspectBits() = \n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_floatLayout_ulp(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout floatLayout
+		value  float64
+		want   float64
+	}{
+		{name: "float64 one", layout: float64Layout, value: 1, want: math.Pow(2, -52)},
+		{name: "float32 one", layout: float32Layout, value: 1, want: math.Pow(2, -23)},
+		{name: "negative value", layout: float64Layout, value: -1, want: math.Pow(2, -52)},
+		{name: "zero", layout: float64Layout, value: 0, want: math.SmallestNonzeroFloat64},
+		{name: "largest float32", layout: float32Layout, value: math.MaxFloat32, want: math.Pow(2, 104)},
+		{name: "NaN", layout: float64Layout, value: math.NaN(), want: math.NaN()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.layout.ulp(tt.value)
+			if got != tt.want && !(math.IsNaN(got) && math.IsNaN(tt.want)) {
+				t.Errorf("floatLayout.ulp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_floatLayout_next(t *testing.T) {
+	if got := float32Layout.nextUp(1); got != 1+math.Pow(2, -23) {
+		t.Errorf("floatLayout.nextUp() = %v", got)
+	}
+	if got := float64Layout.nextDown(1); got != 1-math.Pow(2, -53) {
+		t.Errorf("floatLayout.nextDown() = %v", got)
+	}
+}
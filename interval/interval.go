@@ -0,0 +1,205 @@
+package interval
+
+// closed intervals of float64 for uncertainty propagation. the bounds are rounded outward, so the
+// interval computed always holds the exact result for every value of the operands. an empty interval
+// has its bounds crossed, it is the result outside the domain of an operation, e.g. the square root of [-2, -1].
+// a point may be NaN, the exact result of e.g. a division by 0, which is not the same as no result at all.
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+type Interval struct {
+	Lo, Hi float64
+}
+
+// Point is the interval holding only x
+func Point(x float64) Interval {
+	return Interval{x, x}
+}
+
+// New is the interval between a and b, in any order
+func New(a, b float64) Interval {
+	if b < a {
+		a, b = b, a
+	}
+	return Interval{a, b}
+}
+
+// Around is x±e
+func Around(x, e float64) Interval {
+	e = math.Abs(e)
+	return outward(x-e, x+e)
+}
+
+func Entire() Interval {
+	return Interval{math.Inf(-1), math.Inf(1)}
+}
+
+func Empty() Interval {
+	return Interval{math.Inf(1), math.Inf(-1)}
+}
+
+func (a Interval) IsEmpty() bool {
+	return a.Lo > a.Hi
+}
+
+// IsPoint tells whether the interval holds a single value, i.e. there's no uncertainty. NaN is a point of its own
+func (a Interval) IsPoint() bool {
+	return a.Lo == a.Hi || (math.IsNaN(a.Lo) && math.IsNaN(a.Hi))
+}
+
+func (a Interval) Contains(x float64) bool {
+	return a.Lo <= x && x <= a.Hi
+}
+
+// Hull is the smallest interval holding a and x
+func (a Interval) Hull(x float64) Interval {
+	if a.IsEmpty() || math.IsNaN(x) {
+		return a
+	}
+	return Interval{math.Min(a.Lo, x), math.Max(a.Hi, x)}
+}
+
+// Radius is the largest distance from x to the bounds, so the interval is within x±radius
+func (a Interval) Radius(x float64) float64 {
+	return math.Max(x-a.Lo, a.Hi-x)
+}
+
+func (a Interval) Add(b Interval) Interval {
+	return outward(a.Lo+b.Lo, a.Hi+b.Hi)
+}
+
+func (a Interval) Sub(b Interval) Interval {
+	return outward(a.Lo-b.Hi, a.Hi-b.Lo)
+}
+
+func (a Interval) Mul(b Interval) Interval {
+	if a.IsEmpty() || b.IsEmpty() {
+		return Empty()
+	}
+
+	products := []float64{mul(a.Lo, b.Lo), mul(a.Lo, b.Hi), mul(a.Hi, b.Lo), mul(a.Hi, b.Hi)}
+	lo, hi := products[0], products[0]
+	for _, p := range products[1:] {
+		lo, hi = math.Min(lo, p), math.Max(hi, p)
+	}
+	return outward(lo, hi)
+}
+
+// Div divides a by b. a divisor reaching 0 from one side gives a half-line, a divisor
+// across 0 gives the entire line as every large value can be reached, and dividing by exactly 0 is empty
+func (a Interval) Div(b Interval) Interval {
+	switch {
+	case a.IsEmpty() || b.IsEmpty() || (b.Lo == 0 && b.Hi == 0):
+		return Empty()
+	case b.Lo > 0 || b.Hi < 0:
+		return a.Mul(outward(1/b.Hi, 1/b.Lo))
+	case a.Lo == 0 && a.Hi == 0:
+		return Point(0)
+	case b.Lo == 0:
+		return a.Mul(Interval{1 / b.Hi, math.Inf(1)})
+	case b.Hi == 0:
+		return a.Mul(Interval{math.Inf(-1), 1 / b.Lo})
+	default:
+		return Entire()
+	}
+}
+
+// Scale multiplies a by f
+func (a Interval) Scale(f float64) Interval {
+	return a.Mul(Point(f))
+}
+
+func (a Interval) Abs() Interval {
+	switch {
+	case a.Lo >= 0:
+		return a
+	case a.Hi <= 0:
+		return Interval{-a.Hi, -a.Lo}
+	default:
+		return Interval{0, math.Max(-a.Lo, a.Hi)}
+	}
+}
+
+// Pow raises a to the power p. a non-integer power is only defined on the non-negative part of a
+func (a Interval) Pow(p float64) Interval {
+	if a.IsEmpty() || math.IsNaN(p) {
+		return Empty()
+	}
+
+	switch {
+	case p == 0:
+		return Point(1)
+	case p < 0 && p == math.Trunc(p):
+		return Point(1).Div(a.Pow(-p))
+	case p == math.Trunc(p) && math.Mod(p, 2) == 1:
+		// odd powers are increasing
+		return outward(math.Pow(a.Lo, p), math.Pow(a.Hi, p))
+	case p == math.Trunc(p):
+		// even powers decrease then increase around 0
+		return a.Abs().increasing(func(x float64) float64 { return math.Pow(x, p) }).nonNegative()
+	}
+
+	if a.Hi < 0 {
+		return Empty()
+	}
+	lo := math.Max(a.Lo, 0)
+	if p > 0 {
+		return outward(math.Pow(lo, p), math.Pow(a.Hi, p)).nonNegative()
+	}
+	return outward(math.Pow(a.Hi, p), math.Pow(lo, p)).nonNegative()
+}
+
+// Root takes the n-th root of a, 2 or 3. the square root is only defined on the non-negative part of a
+func (a Interval) Root(n int) Interval {
+	switch {
+	case a.IsEmpty():
+		return Empty()
+	case n == 3:
+		return outward(math.Cbrt(a.Lo), math.Cbrt(a.Hi))
+	case n != 2 || a.Hi < 0:
+		return Empty()
+	default:
+		return Interval{math.Max(a.Lo, 0), a.Hi}.increasing(math.Sqrt).nonNegative()
+	}
+}
+
+// increasing applies the increasing function f on the bounds
+func (a Interval) increasing(f func(float64) float64) Interval {
+	return outward(f(a.Lo), f(a.Hi))
+}
+
+// nonNegative keeps the rounding of a result known to be non-negative from going below 0
+func (a Interval) nonNegative() Interval {
+	return Interval{math.Max(a.Lo, 0), a.Hi}
+}
+
+func (a Interval) String() string {
+	if a.IsEmpty() {
+		return "[]"
+	}
+	return fmt.Sprintf("[%s, %s]", strconv.FormatFloat(a.Lo, 'g', -1, 64), strconv.FormatFloat(a.Hi, 'g', -1, 64))
+}
+
+// mul multiplies the bounds with 0 times infinity being 0, as the bound is reached by a finite value
+func mul(x, y float64) float64 {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	return x * y
+}
+
+// outward moves the bounds to the next float away from each other to cover the rounding of lo and hi.
+// a single value stays a single value, as it is rounded the same way the calculator rounds
+func outward(lo, hi float64) Interval {
+	if math.IsNaN(lo) || math.IsNaN(hi) {
+		return Empty()
+	}
+	if lo == hi {
+		return Interval{lo, hi}
+	}
+	return Interval{math.Nextafter(lo, math.Inf(-1)), math.Nextafter(hi, math.Inf(1))}
+}
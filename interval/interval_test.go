@@ -0,0 +1,77 @@
+package interval
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterval(t *testing.T) {
+	inf := math.Inf(1)
+
+	tests := []struct {
+		name   string
+		got    Interval
+		lo, hi float64
+	}{
+		{name: "add", got: New(1, 2).Add(New(10, 20)), lo: 11, hi: 22},
+		{name: "sub", got: New(1, 2).Sub(New(10, 20)), lo: -19, hi: -8},
+		{name: "mul across zero", got: New(-1, 2).Mul(New(3, 4)), lo: -4, hi: 8},
+		{name: "mul negatives", got: New(-2, -1).Mul(New(-4, -3)), lo: 3, hi: 8},
+		{name: "div", got: New(1, 2).Div(New(4, 8)), lo: 0.125, hi: 0.5},
+		{name: "div by a divisor from 0", got: New(1, 2).Div(New(0, 4)), lo: 0.25, hi: inf},
+		{name: "div by a divisor to 0", got: New(1, 2).Div(New(-4, 0)), lo: -inf, hi: -0.25},
+		{name: "div through zero", got: New(1, 2).Div(New(-1, 1)), lo: -inf, hi: inf},
+		{name: "div zero through zero", got: Point(0).Div(New(-1, 1)), lo: 0, hi: 0},
+		{name: "abs across zero", got: New(-3, 2).Abs(), lo: 0, hi: 3},
+		{name: "even pow across zero", got: New(-3, 2).Pow(2), lo: 0, hi: 9},
+		{name: "even pow of negatives", got: New(-3, -2).Pow(2), lo: 4, hi: 9},
+		{name: "odd pow", got: New(-3, 2).Pow(3), lo: -27, hi: 8},
+		{name: "negative pow", got: New(2, 4).Pow(-1), lo: 0.25, hi: 0.5},
+		{name: "negative even pow across zero", got: New(-1, 2).Pow(-2), lo: 0.25, hi: inf},
+		{name: "fractional pow clips the domain", got: New(-4, 16).Pow(0.5), lo: 0, hi: 4},
+		{name: "zero pow", got: New(-4, 16).Pow(0), lo: 1, hi: 1},
+		{name: "sqrt clips the domain", got: New(-4, 16).Root(2), lo: 0, hi: 4},
+		{name: "cbrt", got: New(-8, 27).Root(3), lo: -2, hi: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the bounds are rounded outward, they are within a few ulps of the exact ones
+			assert.True(t, tt.got.Lo <= tt.lo && tt.got.Hi >= tt.hi, "%v doesn't hold [%v, %v]", tt.got, tt.lo, tt.hi)
+			assert.InDelta(t, tt.lo, tt.got.Lo, 1e-12)
+			assert.InDelta(t, tt.hi, tt.got.Hi, 1e-12)
+		})
+	}
+}
+
+func TestInterval_Empty(t *testing.T) {
+	assert.True(t, New(1, 2).Div(Point(0)).IsEmpty())
+	assert.True(t, New(-4, -1).Root(2).IsEmpty())
+	assert.True(t, New(-4, -1).Pow(0.5).IsEmpty())
+	assert.True(t, Empty().Add(New(1, 2)).IsEmpty())
+	assert.Equal(t, "[]", Empty().String())
+}
+
+func TestInterval_Outward(t *testing.T) {
+	// the sum of the floats 0.1 and 0.2 is rounded up to 0.30000000000000004, the bounds still hold the exact sum
+	x, y := 0.1, 0.2
+	sum := New(x, x).Add(New(y, 0.3))
+	assert.Less(t, sum.Lo, x+y)
+	assert.Greater(t, sum.Hi, x+0.3)
+
+	// a single value isn't widened
+	assert.True(t, Point(x).Add(Point(y)).IsPoint())
+
+	a := Around(10, 0.5)
+	assert.True(t, a.Contains(9.5) && a.Contains(10.5))
+	assert.InDelta(t, 0.5, a.Radius(10), 1e-12)
+}
+
+func TestInterval_NaN(t *testing.T) {
+	// NaN is the exact result of a division by 0, not an empty interval
+	nan := Point(math.NaN())
+	assert.True(t, nan.IsPoint())
+	assert.False(t, nan.IsEmpty())
+	assert.False(t, Empty().IsPoint())
+}
@@ -0,0 +1,284 @@
+package main
+
+// numeric literals accepted as operand. on top of the decimals of the locale it reads
+// hex 0x1F, binary 0b101, octal 0o17, fractions 1/3, percentages 15%, underscores 1_000_000,
+// SI suffixes 3k and 2.5M, mixed numbers "1 1/2" which come as two arguments, and sexagesimal
+// numbers 12°30'15" and 1:30:15.
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gitlab.com/atthoriq/calculator-project/sigfig"
+)
+
+// operand is a parsed numeric argument. percent keeps that the value was typed as percentage, e.g. 15% is 0.15,
+// tolerance is the uncertainty typed after the value, e.g. 0.5 of 10±0.5, and figures the precision inferred from how it was typed
+type operand struct {
+	value     float64
+	percent   bool
+	tolerance float64
+	figures   sigfig.Figures
+}
+
+var siSuffixes = map[rune]float64{
+	'p': 1e-12,
+	'n': 1e-9,
+	'u': 1e-6,
+	'µ': 1e-6,
+	'm': 1e-3,
+	'k': 1e3,
+	'M': 1e6,
+	'G': 1e9,
+	'T': 1e12,
+	'P': 1e15,
+}
+
+// literalError points at the offending character of the input
+type literalError struct {
+	input string
+	pos   int // position of the offending rune, starting from 1
+	msg   string
+}
+
+func (e *literalError) Error() string {
+	return fmt.Sprintf("invalid number %q: %s at position %d", e.input, e.msg, e.pos)
+}
+
+// parseOperand reads one or two arguments as operand, two arguments being a mixed number like "1 1/2".
+// degrees, minutes and seconds may be typed apart like 12° 30' 15"
+func (l locale) parseOperand(args []string) (operand, error) {
+	if len(args) > 1 && len(args) <= 3 && isSexagesimal(args[0]) {
+		return l.parseLiteral(strings.Join(args, ""))
+	}
+
+	switch len(args) {
+	case 1:
+		return l.parseLiteral(args[0])
+	case 2:
+		whole, fraction := args[0], args[1]
+		if !strings.Contains(fraction, "/") || strings.HasPrefix(fraction, "-") || strings.HasPrefix(fraction, "+") {
+			return operand{}, errInvalidInput
+		}
+		w, err := l.parseLiteral(whole)
+		if err != nil {
+			return operand{}, err
+		}
+		if w.percent || w.value != math.Trunc(w.value) {
+			return operand{}, fmt.Errorf("invalid mixed number %q: the whole part must be an integer", whole+" "+fraction)
+		}
+		f, err := l.parseLiteral(fraction)
+		if err != nil {
+			return operand{}, err
+		}
+		if f.percent {
+			return operand{}, errInvalidInput
+		}
+		if math.Signbit(w.value) {
+			return operand{value: w.value - f.value}, nil
+		}
+		return operand{value: w.value + f.value}, nil
+	default:
+		return operand{}, errInvalidInput
+	}
+}
+
+// parseLiteral reads a single numeric literal
+func (l locale) parseLiteral(s string) (operand, error) {
+	if isSexagesimal(s) {
+		v, err := l.parseSexagesimal(s)
+		if err != nil {
+			return operand{}, err
+		}
+		return operand{value: v}, nil
+	}
+
+	if number, found := strings.CutSuffix(s, "%"); found {
+		v, err := l.parseNumber(s, number, 0)
+		if err != nil {
+			return operand{}, err
+		}
+		return operand{value: v / 100, percent: true}, nil
+	}
+
+	v, err := l.parseNumber(s, s, 0)
+	if err != nil {
+		return operand{}, err
+	}
+	return operand{value: v, figures: l.figures(s)}, nil
+}
+
+// parseNumber reads s which starts at offset of input, the offset is used to point at the offending character
+func (l locale) parseNumber(input, s string, offset int) (float64, error) {
+	if s == "" {
+		return 0, &literalError{input, offset + 1, "missing digits"}
+	}
+
+	if numerator, denominator, found := strings.Cut(s, "/"); found {
+		n, err := l.parseNumber(input, numerator, offset)
+		if err != nil {
+			return 0, err
+		}
+		dOffset := offset + len([]rune(numerator)) + 1
+		if i := strings.Index(denominator, "/"); i >= 0 {
+			return 0, &literalError{input, dOffset + len([]rune(denominator[:i])) + 1, "too many slashes"}
+		}
+		if strings.HasPrefix(denominator, "-") || strings.HasPrefix(denominator, "+") {
+			return 0, &literalError{input, dOffset + 1, "unexpected sign in the denominator"}
+		}
+		d, err := l.parseNumber(input, denominator, dOffset)
+		if err != nil {
+			return 0, err
+		}
+		if d == 0 {
+			return 0, &literalError{input, dOffset + 1, "denominator is 0"}
+		}
+		return n / d, nil
+	}
+
+	body := strings.TrimLeft(s, "+-")
+	sign := 1.0
+	if len(s)-len(body) > 1 {
+		return 0, &literalError{input, offset + 2, "unexpected sign"}
+	}
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+	}
+	offset += len(s) - len(body)
+	if body == "" {
+		return 0, &literalError{input, offset + 1, "missing digits"}
+	}
+
+	if len(body) > 2 && body[0] == '0' {
+		base := map[byte]int{'x': 16, 'X': 16, 'b': 2, 'B': 2, 'o': 8, 'O': 8}[body[1]]
+		if base != 0 {
+			v, err := parseInteger(input, body[2:], base, offset+2)
+			return sign * v, err
+		}
+	}
+
+	runes := []rune(body)
+	if factor, ok := siSuffixes[runes[len(runes)-1]]; ok && len(runes) > 1 {
+		v, err := l.parseDecimal(input, string(runes[:len(runes)-1]), offset)
+		return sign * v * factor, err
+	}
+
+	v, err := l.parseDecimal(input, body, offset)
+	return sign * v, err
+}
+
+// parseInteger reads the digits of a hex, binary or octal literal, underscores may separate the digits
+func parseInteger(input, digits string, base int, offset int) (float64, error) {
+	if digits == "" {
+		return 0, &literalError{input, offset + 1, "missing digits"}
+	}
+
+	value := 0.0
+	previous := '_'
+	for i, r := range []rune(digits) {
+		if r == '_' {
+			if previous == '_' || i == len([]rune(digits))-1 {
+				return 0, &literalError{input, offset + i + 1, "misplaced underscore"}
+			}
+			previous = r
+			continue
+		}
+
+		d, err := strconv.ParseInt(string(r), base, 64)
+		if err != nil {
+			return 0, &literalError{input, offset + i + 1, fmt.Sprintf("unexpected %q in base %d", r, base)}
+		}
+		value = value*float64(base) + float64(d)
+		previous = r
+	}
+
+	return value, nil
+}
+
+// parseDecimal reads a decimal number in the locale, underscores may separate the digits
+func (l locale) parseDecimal(input, s string, offset int) (float64, error) {
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case r >= '0' && r <= '9', r == 'e', r == 'E', r == '+', r == '-':
+		case r == '_':
+			if l.isGroup(r) {
+				continue
+			}
+			if i == 0 || i == len(runes)-1 || !isDigit(runes[i-1]) || !isDigit(runes[i+1]) {
+				return 0, &literalError{input, offset + i + 1, "misplaced underscore"}
+			}
+		case l.tag == "" && r == '.', l.tag != "" && (r == l.decimal || l.isGroup(r)):
+		default:
+			if l.tag == "" && isSpecialFloat(s) {
+				return strconv.ParseFloat(s, 64)
+			}
+			return 0, &literalError{input, offset + i + 1, fmt.Sprintf("unexpected %q", r)}
+		}
+	}
+
+	if !l.isGroup('_') {
+		s = strings.ReplaceAll(s, "_", "")
+	}
+	v, err := l.parse(s)
+	if err != nil {
+		if l.tag == "" {
+			if pos, msg := malformedDecimal(runes); pos > 0 {
+				return 0, &literalError{input, offset + pos, msg}
+			}
+			return 0, fmt.Errorf("invalid number %q", input)
+		}
+		return 0, err
+	}
+	return v, nil
+}
+
+// malformedDecimal finds what breaks digits[.digits][e[sign]digits] in runes, the position starts from 1
+// and is 0 when nothing does
+func malformedDecimal(runes []rune) (int, string) {
+	var digits, dot, exponent, exponentDigits bool
+	previous := rune(0)
+	for i, r := range runes {
+		switch {
+		case r == '_':
+			continue
+		case isDigit(r):
+			if exponent {
+				exponentDigits = true
+			} else {
+				digits = true
+			}
+		case r == '.' && !dot && !exponent:
+			dot = true
+		case (r == 'e' || r == 'E') && digits && !exponent:
+			exponent = true
+		case (r == '+' || r == '-') && (previous == 'e' || previous == 'E'):
+		default:
+			return i + 1, fmt.Sprintf("unexpected %q", r)
+		}
+		previous = r
+	}
+
+	switch {
+	case !digits:
+		return len(runes) + 1, "missing digits"
+	case exponent && !exponentDigits:
+		return len(runes) + 1, "missing digits in the exponent"
+	}
+	return 0, ""
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// isSpecialFloat reports whether s is infinity or NaN as spelled by strconv
+func isSpecialFloat(s string) bool {
+	switch strings.ToLower(s) {
+	case "inf", "infinity", "nan":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"gitlab.com/atthoriq/calculator-project/sigfig"
+)
+
+func Test_locale_parseOperand(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		args    []string
+		want    operand
+		wantErr string
+	}{
+		{name: "decimal", args: []string{"2.5"}, want: operand{value: 2.5, figures: sigfig.At(-1)}},
+		{name: "exponent", args: []string{"-1.5e3"}, want: operand{value: -1500, figures: sigfig.At(2)}},
+		{name: "infinity", args: []string{"-inf"}, want: operand{value: math.Inf(-1)}},
+		{name: "hex", args: []string{"0x1F"}, want: operand{value: 31}},
+		{name: "negative hex", args: []string{"-0xff"}, want: operand{value: -255}},
+		{name: "binary with underscore", args: []string{"0b1010_0101"}, want: operand{value: 165}},
+		{name: "octal", args: []string{"0o17"}, want: operand{value: 15}},
+		{name: "fraction", args: []string{"1/4"}, want: operand{value: 0.25}},
+		{name: "negative fraction", args: []string{"-3/2"}, want: operand{value: -1.5}},
+		{name: "percentage", args: []string{"15%"}, want: operand{value: 0.15, percent: true}},
+		{name: "underscores", args: []string{"1_000_000"}, want: operand{value: 1e6, figures: sigfig.At(6)}},
+		{name: "kilo", args: []string{"3k"}, want: operand{value: 3000, figures: sigfig.At(3)}},
+		{name: "mega with decimals", args: []string{"2.5M"}, want: operand{value: 2.5e6, figures: sigfig.At(5)}},
+		{name: "milli", args: []string{"250m"}, want: operand{value: 0.25, figures: sigfig.At(-2)}},
+		{name: "mixed number", args: []string{"1", "1/2"}, want: operand{value: 1.5}},
+		{name: "negative mixed number", args: []string{"-2", "3/4"}, want: operand{value: -2.75}},
+		{name: "fraction in german", tag: "de-DE", args: []string{"1,5/3"}, want: operand{value: 0.5}},
+		{name: "percentage in german", tag: "de-DE", args: []string{"12,5%"}, want: operand{value: 0.125, percent: true}},
+		{name: "degrees and minutes", args: []string{"12°30'"}, want: operand{value: 12.5}},
+		{name: "degrees, minutes and seconds", args: []string{"-0°45'36\""}, want: operand{value: -0.76}},
+		{name: "seconds as two apostrophes", args: []string{"0°0'36''"}, want: operand{value: 0.01}},
+		{name: "minutes only", args: []string{"30'"}, want: operand{value: 0.5}},
+		{name: "southern hemisphere", args: []string{"45°S"}, want: operand{value: -45}},
+		{name: "degrees typed apart", args: []string{"12°", "30'"}, want: operand{value: 12.5}},
+		{name: "hours and minutes", args: []string{"-1:30"}, want: operand{value: -1.5}},
+		{name: "hours, minutes and seconds", args: []string{"1:15:00"}, want: operand{value: 1.25}},
+		{name: "apostrophe groups in swiss german", tag: "de-CH", args: []string{"1'000.5"}, want: operand{value: 1000.5, figures: sigfig.At(-1)}},
+		{
+			name:    "hex digit out of range",
+			args:    []string{"0x1G"},
+			wantErr: `invalid number "0x1G": unexpected 'G' in base 16 at position 4`,
+		},
+		{
+			name:    "binary digit out of range",
+			args:    []string{"0b102"},
+			wantErr: `invalid number "0b102": unexpected '2' in base 2 at position 5`,
+		},
+		{
+			name:    "unexpected letter",
+			args:    []string{"12a4"},
+			wantErr: `invalid number "12a4": unexpected 'a' at position 3`,
+		},
+		{
+			name:    "double underscore",
+			args:    []string{"1__000"},
+			wantErr: `invalid number "1__000": misplaced underscore at position 2`,
+		},
+		{
+			name:    "trailing underscore in hex",
+			args:    []string{"0xff_"},
+			wantErr: `invalid number "0xff_": misplaced underscore at position 5`,
+		},
+		{
+			name:    "division by zero",
+			args:    []string{"1/0"},
+			wantErr: `invalid number "1/0": denominator is 0 at position 3`,
+		},
+		{
+			name:    "missing denominator",
+			args:    []string{"1/"},
+			wantErr: `invalid number "1/": missing digits at position 3`,
+		},
+		{
+			name:    "fraction of a fraction",
+			args:    []string{"1/3/4"},
+			wantErr: `invalid number "1/3/4": too many slashes at position 4`,
+		},
+		{
+			name:    "minus alone",
+			args:    []string{"-"},
+			wantErr: `invalid number "-": missing digits at position 2`,
+		},
+		{
+			name:    "plus alone",
+			args:    []string{"+"},
+			wantErr: `invalid number "+": missing digits at position 2`,
+		},
+		{
+			name:    "minus percent",
+			args:    []string{"-%"},
+			wantErr: `invalid number "-%": missing digits at position 2`,
+		},
+		{
+			name:    "missing exponent",
+			args:    []string{"1e"},
+			wantErr: `invalid number "1e": missing digits in the exponent at position 3`,
+		},
+		{
+			name:    "exponent without mantissa",
+			args:    []string{"-e5"},
+			wantErr: `invalid number "-e5": unexpected 'e' at position 2`,
+		},
+		{
+			name:    "sign inside the number",
+			args:    []string{"1.5-3"},
+			wantErr: `invalid number "1.5-3": unexpected '-' at position 4`,
+		},
+		{
+			name:    "comma in C locale",
+			args:    []string{"1,2"},
+			wantErr: `invalid number "1,2": unexpected ',' at position 2`,
+		},
+		{
+			name:    "mixed number with decimal whole part",
+			args:    []string{"1.5", "1/2"},
+			wantErr: `invalid mixed number "1.5 1/2": the whole part must be an integer`,
+		},
+		{
+			name:    "minutes above 60",
+			args:    []string{"12°75'"},
+			wantErr: `invalid number "12°75'": 75 is not below 60 at position 4`,
+		},
+		{
+			name:    "marks out of order",
+			args:    []string{"12'30°"},
+			wantErr: `invalid number "12'30°": unexpected '°' at position 6`,
+		},
+		{
+			name:    "missing mark",
+			args:    []string{"12°30"},
+			wantErr: `invalid number "12°30": missing °, ' or " mark at position 4`,
+		},
+		{
+			name:    "too many colons",
+			args:    []string{"1:2:3:4"},
+			wantErr: `invalid number "1:2:3:4": too many colons at position 6`,
+		},
+		{
+			name:    "two plain numbers",
+			args:    []string{"20", "5"},
+			wantErr: errInvalidInput.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := locale{}
+			if tt.tag != "" {
+				l, _ = findLocale(tt.tag)
+			}
+			got, err := l.parseOperand(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("locale.parseOperand() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("locale.parseOperand() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("locale.parseOperand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
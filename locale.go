@@ -0,0 +1,209 @@
+package main
+
+// locale decides how numbers are typed and printed: the decimal separator, the grouping separator and the size of the digit groups.
+// the zero value is the "C" locale which is what strconv reads and writes, a dot as decimal separator and no grouping.
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	narrowNoBreakSpace = '\u202f'
+	noBreakSpace       = '\u00a0'
+)
+
+type locale struct {
+	tag     string
+	decimal rune
+	group   rune
+	// grouping is the size of the digit groups counted from the decimal separator, the last size repeats
+	grouping []int
+}
+
+var locales = map[string]locale{
+	"en-US": {"en-US", '.', ',', []int{3}},
+	"en-GB": {"en-GB", '.', ',', []int{3}},
+	"en-IN": {"en-IN", '.', ',', []int{3, 2}},
+	"id-ID": {"id-ID", ',', '.', []int{3}},
+	"de-DE": {"de-DE", ',', '.', []int{3}},
+	"es-ES": {"es-ES", ',', '.', []int{3}},
+	"pt-BR": {"pt-BR", ',', '.', []int{3}},
+	"fr-FR": {"fr-FR", ',', narrowNoBreakSpace, []int{3}},
+	"de-CH": {"de-CH", '.', '\'', []int{3}},
+}
+
+// findLocale looks the tag up, accepting posix names like "de_DE.UTF-8" as well. "C" and "POSIX" are the default locale
+func findLocale(tag string) (locale, error) {
+	name, _, _ := strings.Cut(tag, ".")
+	name, _, _ = strings.Cut(name, "@")
+	name = strings.ReplaceAll(name, "_", "-")
+	if name == "C" || name == "POSIX" {
+		return locale{}, nil
+	}
+
+	for key, loc := range locales {
+		if strings.EqualFold(key, name) {
+			return loc, nil
+		}
+	}
+
+	return locale{}, fmt.Errorf("unknown locale %q", tag)
+}
+
+// localeFromEnv picks the locale of the numbers from the environment the same way libc does
+func localeFromEnv() locale {
+	for _, key := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if value := os.Getenv(key); value != "" {
+			loc, err := findLocale(value)
+			if err != nil {
+				return locale{}
+			}
+			return loc
+		}
+	}
+
+	return locale{}
+}
+
+func (l locale) String() string {
+	if l.tag == "" {
+		return "C"
+	}
+	return l.tag
+}
+
+func (l locale) isGroup(r rune) bool {
+	if l.group == narrowNoBreakSpace {
+		// nobody types a narrow no-break space, accept its look-alikes
+		return r == narrowNoBreakSpace || r == noBreakSpace || r == '_'
+	}
+	return r == l.group
+}
+
+// parse reads a number written in the locale. grouping must be regular and a number that reads as well
+// with the decimal separator of other locales is rejected, e.g. "1,234" might be 1234 or 1.234
+func (l locale) parse(s string) (float64, error) {
+	if l.tag == "" {
+		return strconv.ParseFloat(s, 64)
+	}
+
+	body := strings.TrimLeft(s, "+-")
+	sign := s[:len(s)-len(body)]
+	if len(sign) > 1 {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+
+	mantissa, exponent := body, ""
+	if i := strings.IndexAny(body, "eE"); i >= 0 {
+		mantissa, exponent = body[:i], body[i:]
+	}
+
+	integer, fraction, hasDecimal := strings.Cut(mantissa, string(l.decimal))
+	if strings.ContainsRune(fraction, l.decimal) {
+		return 0, fmt.Errorf("invalid number %q: decimal separator %q is written twice", s, l.decimal)
+	}
+	for _, r := range fraction {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid number %q: unexpected %q after the decimal separator", s, r)
+		}
+	}
+
+	digits, groups, err := l.ungroup(integer)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q: %w", s, err)
+	}
+	if groups == 1 && !hasDecimal && exponent == "" {
+		return 0, fmt.Errorf("ambiguous number %q: write %s or %s%c0", s, digits, integer, l.decimal)
+	}
+
+	normalized := sign + digits
+	if hasDecimal {
+		normalized += "." + fraction
+	}
+	v, err := strconv.ParseFloat(normalized+exponent, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return v, nil
+}
+
+// ungroup removes the grouping separators from the integer part, checking the groups have the size of the locale
+func (l locale) ungroup(integer string) (digits string, groups int, err error) {
+	parts := []string{""}
+	for _, r := range integer {
+		switch {
+		case l.isGroup(r):
+			parts = append(parts, "")
+		case r >= '0' && r <= '9':
+			parts[len(parts)-1] += string(r)
+		default:
+			return "", 0, fmt.Errorf("unexpected %q", r)
+		}
+	}
+
+	for _, part := range parts {
+		if part == "" && len(parts) > 1 {
+			return "", 0, fmt.Errorf("misplaced grouping separator")
+		}
+	}
+	if parts[0] == "" {
+		return "", 0, fmt.Errorf("missing digits")
+	}
+
+	// every group but the leftmost must have exactly the size of the locale
+	for i := len(parts) - 1; i > 0; i-- {
+		if len(parts[i]) != l.groupSize(len(parts)-1-i) {
+			return "", 0, fmt.Errorf("digit group %q has the wrong size", parts[i])
+		}
+	}
+	if len(parts) > 1 && len(parts[0]) > l.groupSize(len(parts)-1) {
+		return "", 0, fmt.Errorf("digit group %q has the wrong size", parts[0])
+	}
+
+	return strings.Join(parts, ""), len(parts) - 1, nil
+}
+
+// groupSize returns the size of the i-th group counted from the decimal separator
+func (l locale) groupSize(i int) int {
+	if i < len(l.grouping) {
+		return l.grouping[i]
+	}
+	return l.grouping[len(l.grouping)-1]
+}
+
+// localize rewrites a number printed by strconv with the separators of the locale
+func (l locale) localize(s string) string {
+	if l.tag == "" {
+		return s
+	}
+
+	body := strings.TrimLeft(s, "+-")
+	sign := s[:len(s)-len(body)]
+	end := strings.IndexFunc(body, func(r rune) bool { return r < '0' || r > '9' })
+	if end < 0 {
+		end = len(body)
+	}
+	if end == 0 {
+		// NaN and infinity
+		return s
+	}
+
+	integer, rest := body[:end], body[end:]
+	rest = strings.Replace(rest, ".", string(l.decimal), 1)
+
+	var groups []string
+	for i := 0; len(integer) > 0; i++ {
+		size := l.groupSize(i)
+		if len(integer) <= size {
+			groups = append([]string{integer}, groups...)
+			break
+		}
+		groups = append([]string{integer[len(integer)-size:]}, groups...)
+		integer = integer[:len(integer)-size]
+	}
+
+	return sign + strings.Join(groups, string(l.group)) + rest
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/atthoriq/calculator-project/calculator"
+)
+
+func Test_locale_parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{
+			name:  "C locale uses strconv",
+			tag:   "C",
+			input: "1234.56",
+			want:  1234.56,
+		},
+		{
+			name:    "C locale rejects grouping",
+			tag:     "C",
+			input:   "1,234.56",
+			wantErr: true,
+		},
+		{
+			name:  "german grouping and decimal comma",
+			tag:   "de-DE",
+			input: "1.234,56",
+			want:  1234.56,
+		},
+		{
+			name:  "german negative with several groups",
+			tag:   "de-DE",
+			input: "-12.345.678,9",
+			want:  -12345678.9,
+		},
+		{
+			name:  "german decimal comma only",
+			tag:   "de-DE",
+			input: "0,5",
+			want:  0.5,
+		},
+		{
+			name:    "german decimal dot is a misplaced group",
+			tag:     "de-DE",
+			input:   "1.5",
+			wantErr: true,
+		},
+		{
+			name:    "single group without decimals is ambiguous",
+			tag:     "de-DE",
+			input:   "1.234",
+			wantErr: true,
+		},
+		{
+			name:    "english single group without decimals is ambiguous",
+			tag:     "en-US",
+			input:   "1,234",
+			wantErr: true,
+		},
+		{
+			name:  "english groups with decimals",
+			tag:   "en-US",
+			input: "1,234.5",
+			want:  1234.5,
+		},
+		{
+			name:    "decimal separator twice",
+			tag:     "en-US",
+			input:   "1.2.3",
+			wantErr: true,
+		},
+		{
+			name:    "irregular group",
+			tag:     "en-US",
+			input:   "12,34.5",
+			wantErr: true,
+		},
+		{
+			name:  "indian lakh grouping",
+			tag:   "en-IN",
+			input: "12,34,567.5",
+			want:  1234567.5,
+		},
+		{
+			name:    "indian grouping in thousands is irregular",
+			tag:     "en-IN",
+			input:   "1,234,567.5",
+			wantErr: true,
+		},
+		{
+			name:  "french grouping with underscore",
+			tag:   "fr-FR",
+			input: "1_234_567,25",
+			want:  1234567.25,
+		},
+		{
+			name:  "swiss apostrophe",
+			tag:   "de-CH",
+			input: "1'234'567.25",
+			want:  1234567.25,
+		},
+		{
+			name:  "exponent is kept",
+			tag:   "id-ID",
+			input: "1,5e3",
+			want:  1500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := findLocale(tt.tag)
+			if err != nil {
+				t.Fatalf("findLocale() error = %v", err)
+			}
+			got, err := l.parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("locale.parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("locale.parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_locale_RoundTrip(t *testing.T) {
+	values := []float64{0, 0.5, -7.25, 999.99, 1234.56, -1234567.89, 100000000.01}
+	for _, tag := range []string{"C", "en-US", "en-IN", "de-DE", "fr-FR", "de-CH", "id-ID"} {
+		t.Run(tag, func(t *testing.T) {
+			l, err := findLocale(tag)
+			if err != nil {
+				t.Fatalf("findLocale() error = %v", err)
+			}
+			for _, v := range values {
+				printed := l.localize(numberFormat{}.format(v))
+				got, err := l.parse(printed)
+				if err != nil {
+					t.Errorf("locale.parse(%q) error = %v", printed, err)
+					continue
+				}
+				assert.Equal(t, v, got, printed)
+			}
+		})
+	}
+}
+
+func Test_locale_localize(t *testing.T) {
+	tests := []struct {
+		name  string
+		tag   string
+		input string
+		want  string
+	}{
+		{
+			name:  "german",
+			tag:   "de-DE",
+			input: "-1234567.89",
+			want:  "-1.234.567,89",
+		},
+		{
+			name:  "indian",
+			tag:   "en-IN",
+			input: "123456789.00",
+			want:  "12,34,56,789.00",
+		},
+		{
+			name:  "french uses narrow no-break space",
+			tag:   "fr-FR",
+			input: "1234.5",
+			want:  "1\u202f234,5",
+		},
+		{
+			name:  "scientific only changes the decimal separator",
+			tag:   "de-DE",
+			input: "1.23e+20",
+			want:  "1,23e+20",
+		},
+		{
+			name:  "engineering prefix is kept",
+			tag:   "id-ID",
+			input: "12.35k",
+			want:  "12,35k",
+		},
+		{
+			name:  "NaN is untouched",
+			tag:   "de-DE",
+			input: "NaN",
+			want:  "NaN",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, _ := findLocale(tt.tag)
+			if got := l.localize(tt.input); got != tt.want {
+				t.Errorf("locale.localize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_findLocale(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{name: "bcp 47 tag", tag: "de-DE", want: "de-DE"},
+		{name: "posix name", tag: "de_DE.UTF-8", want: "de-DE"},
+		{name: "case insensitive", tag: "EN-us", want: "en-US"},
+		{name: "C locale", tag: "C.UTF-8", want: "C"},
+		{name: "unknown", tag: "xx-YY", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findLocale(tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("findLocale() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("findLocale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_calculatorHandler_Handle_Locale(t *testing.T) {
+	ch := InitCalculatorHandler(calculator.InitNewCalculator())
+	var outputs []string
+	for _, command := range []string{"locale de_DE.UTF-8", "add 1.234,5", "multiply 1.000,0", "locale", "locale C", "divide 1000"} {
+		got, err := ch.Handle(command)
+		if err != nil {
+			t.Fatalf("calculatorHandler.Handle(%q) error = %v", command, err)
+		}
+		outputs = append(outputs, got)
+	}
+	assert.Equal(t, []string{"locale de-DE", "1.234,50", "1.234.500,00", "de-DE", "locale C", "1234.50"}, outputs)
+
+	_, err := ch.Handle("locale xx")
+	assert.Error(t, err)
+}
@@ -2,14 +2,19 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"gitlab.com/atthoriq/calculator-project/calculator"
 )
 
 func main() {
+	format := flag.String("format", "", "result format, e.g. \"fixed 4\", \"sig 3\", \"sci 2\", \"eng 1\" or \"auto\"")
+	flag.Parse()
+
 	fmt.Println("Welcome to The Calculator!")
 	defer fmt.Println("Good bye!")
 
@@ -19,6 +24,14 @@ func main() {
 	if handler == nil {
 		log.Fatal("fail initializing handler")
 	}
+	if *format != "" {
+		f, err := parseNumberFormat(strings.Fields(*format))
+		if err != nil {
+			log.Fatal("invalid format: ", err)
+		}
+		handler.numberFormat = f
+	}
+	handler.locale = localeFromEnv()
 
 	// run the scanner
 	inputScanner(handler)
@@ -0,0 +1,180 @@
+package main
+
+// handler of the matrix mode. the running value is a matrix.Matrix instead of the calculator, a matrix,
+// a vector or a scalar typed as [1 2; 3 4], [1 2 3] or 5, and every matrix result is shown as an aligned grid.
+// a scalar goes back and forth with current when switching modes, a matrix stays in the mode until it comes back.
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gitlab.com/atthoriq/calculator-project/matrix"
+)
+
+const (
+	transposeOp = "transpose"
+	detOp       = "det"
+	inverseOp   = "inverse"
+	solveOp     = "solve"
+	dotOp       = "dot"
+	crossOp     = "cross"
+	normOp      = "norm"
+)
+
+// handleMatrix handles the commands of the matrix mode
+func (ch *calculatorHandler) handleMatrix(op string, args []string) (string, error) {
+	m := ch.matrix
+
+	switch op {
+	case add, subtract, multiply, solveOp, dotOp, crossOp:
+		n, err := ch.parseMatrix(args)
+		if err != nil {
+			return "", err
+		}
+
+		var res matrix.Matrix
+		switch op {
+		case add:
+			res, err = m.Add(n)
+		case subtract:
+			res, err = m.Sub(n)
+		case multiply:
+			res, err = m.Mul(n)
+		case solveOp:
+			res, err = m.Solve(n)
+		case dotOp:
+			var dot float64
+			dot, err = matrix.Dot(m, n)
+			res = matrix.Scalar(dot)
+		case crossOp:
+			res, err = matrix.Cross(m, n)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		ch.matrix = res
+		return res.Format(ch.format), nil
+	case divide:
+		n, err := ch.parseMatrix(args)
+		if err != nil {
+			return "", err
+		}
+		if !n.IsScalar() {
+			return "", errors.New("can't divide by a matrix: use inverse or solve")
+		}
+
+		ch.matrix = m.Scale(1 / n[0][0])
+		return ch.matrix.Format(ch.format), nil
+	case transposeOp, detOp, inverseOp, cancel:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
+		switch op {
+		case transposeOp:
+			ch.matrix = m.Transpose()
+		case detOp:
+			det, err := m.Det()
+			if err != nil {
+				return "", err
+			}
+			ch.matrix = matrix.Scalar(det)
+		case inverseOp:
+			inv, err := m.Inverse()
+			if err != nil {
+				return "", err
+			}
+			ch.matrix = inv
+		case cancel:
+			ch.matrix = matrix.Scalar(0)
+		}
+
+		return ch.matrix.Format(ch.format), nil
+	case normOp:
+		kind := matrix.Norm2
+		switch len(args) {
+		case 0:
+		case 1:
+			kind = args[0]
+		default:
+			return "", errInvalidInput
+		}
+
+		norm, err := m.Norm(kind)
+		if err != nil {
+			return "", err
+		}
+
+		ch.matrix = matrix.Scalar(norm)
+		return ch.format(norm), nil
+	default:
+		return "", fmt.Errorf("%s is not supported in matrix mode", op)
+	}
+}
+
+// parseMatrix reads a matrix like [1 2; 3 4], the rows separated by semicolons, or a scalar. the arguments
+// are joined again, as the elements are separated by spaces, and by commas as well in the C locale
+func (ch *calculatorHandler) parseMatrix(args []string) (matrix.Matrix, error) {
+	if len(args) == 0 {
+		return nil, errInvalidInput
+	}
+
+	text := strings.Join(args, " ")
+	if !strings.HasPrefix(text, "[") {
+		v, err := ch.parseOperand(args)
+		if err != nil {
+			return nil, err
+		}
+		if v.percent {
+			return nil, errInvalidInput
+		}
+		return matrix.Scalar(v.value), nil
+	}
+
+	inner, found := strings.CutSuffix(text[1:], "]")
+	if !found {
+		return nil, fmt.Errorf("invalid matrix %q: missing ]", text)
+	}
+
+	separator := func(r rune) bool {
+		return unicode.IsSpace(r) || (r == ',' && ch.locale.tag == "")
+	}
+	var rows [][]float64
+	for _, line := range strings.Split(inner, ";") {
+		row := []float64{}
+		for _, element := range strings.FieldsFunc(line, separator) {
+			v, err := ch.locale.parseLiteral(element)
+			if err != nil {
+				return nil, err
+			}
+			if v.percent {
+				return nil, fmt.Errorf("invalid matrix %q: unexpected percentage %s", text, element)
+			}
+			row = append(row, v.value)
+		}
+		rows = append(rows, row)
+	}
+
+	m, err := matrix.New(rows)
+	if err != nil {
+		return nil, fmt.Errorf("invalid matrix %q: %w", text, err)
+	}
+	return m, nil
+}
+
+// enterMatrix starts the matrix mode with current as scalar, unless a matrix or a vector was left in the mode
+func (ch *calculatorHandler) enterMatrix() {
+	if ch.matrix == nil || ch.matrix.IsScalar() {
+		ch.matrix = matrix.Scalar(ch.calculator.GetResult())
+	}
+}
+
+// leaveMatrix writes a scalar back to current, a matrix or a vector stays in the mode
+func (ch *calculatorHandler) leaveMatrix() {
+	if ch.matrix.IsScalar() {
+		ch.setCurrent(ch.matrix[0][0])
+	}
+}
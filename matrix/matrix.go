@@ -0,0 +1,452 @@
+package matrix
+
+// dense matrices of float64 for the matrix mode. a vector is a matrix of a single row or a single column
+// and a scalar a matrix of a single element, so a running value is always a Matrix. the solvers use
+// Gaussian elimination with partial pivoting, a pivot negligible next to the entries means singular.
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// Matrix is a slice of rows of the same length
+type Matrix [][]float64
+
+const (
+	Norm1         = "1"
+	Norm2         = "2"
+	NormInf       = "inf"
+	NormFrobenius = "fro"
+)
+
+var ErrSingular = errors.New("matrix is singular")
+
+// New checks that the rows are neither empty nor ragged
+func New(rows [][]float64) (Matrix, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, errors.New("empty matrix")
+	}
+	for i, row := range rows {
+		if len(row) != len(rows[0]) {
+			return nil, fmt.Errorf("row %d has %d elements, expected %d", i+1, len(row), len(rows[0]))
+		}
+	}
+	return Matrix(rows), nil
+}
+
+func Scalar(x float64) Matrix {
+	return Matrix{{x}}
+}
+
+// Identity is the n×n identity matrix
+func Identity(n int) Matrix {
+	m := zeros(n, n)
+	for i := range m {
+		m[i][i] = 1
+	}
+	return m
+}
+
+func (m Matrix) Rows() int {
+	return len(m)
+}
+
+func (m Matrix) Cols() int {
+	return len(m[0])
+}
+
+func (m Matrix) IsScalar() bool {
+	return m.Rows() == 1 && m.Cols() == 1
+}
+
+// IsVector tells whether m is a single row or a single column
+func (m Matrix) IsVector() bool {
+	return m.Rows() == 1 || m.Cols() == 1
+}
+
+// Shape writes the dimensions, e.g. 2×3
+func (m Matrix) Shape() string {
+	return fmt.Sprintf("%d×%d", m.Rows(), m.Cols())
+}
+
+// Add sums m and n element by element. a scalar is added to every element of the other
+func (m Matrix) Add(n Matrix) (Matrix, error) {
+	return m.elementwise(n, "add", func(a, b float64) float64 { return a + b })
+}
+
+func (m Matrix) Sub(n Matrix) (Matrix, error) {
+	return m.elementwise(n, "subtract", func(a, b float64) float64 { return a - b })
+}
+
+// Mul is the matrix product of m and n, or the product by a scalar when either is one
+func (m Matrix) Mul(n Matrix) (Matrix, error) {
+	switch {
+	case n.IsScalar():
+		return m.Scale(n[0][0]), nil
+	case m.IsScalar():
+		return n.Scale(m[0][0]), nil
+	case m.Cols() != n.Rows():
+		return nil, fmt.Errorf("can't multiply %s by %s: %d columns against %d rows", m.Shape(), n.Shape(), m.Cols(), n.Rows())
+	}
+
+	p := zeros(m.Rows(), n.Cols())
+	for i := range p {
+		for j := range p[i] {
+			for k := range n {
+				p[i][j] += m[i][k] * n[k][j]
+			}
+		}
+	}
+	return p, nil
+}
+
+func (m Matrix) Scale(f float64) Matrix {
+	s := zeros(m.Rows(), m.Cols())
+	for i := range m {
+		for j := range m[i] {
+			s[i][j] = m[i][j] * f
+		}
+	}
+	return s
+}
+
+func (m Matrix) Transpose() Matrix {
+	t := zeros(m.Cols(), m.Rows())
+	for i := range m {
+		for j := range m[i] {
+			t[j][i] = m[i][j]
+		}
+	}
+	return t
+}
+
+// Det is the determinant of a square m
+func (m Matrix) Det() (float64, error) {
+	if err := m.square("determinant"); err != nil {
+		return 0, err
+	}
+
+	a := m.clone()
+	det := 1.0
+	for k := range a {
+		p := pivot(a, k)
+		if a[p][k] == 0 {
+			return 0, nil
+		}
+		if p != k {
+			a[p], a[k] = a[k], a[p]
+			det = -det
+		}
+		det *= a[k][k]
+		for i := k + 1; i < len(a); i++ {
+			f := a[i][k] / a[k][k]
+			for j := k; j < len(a); j++ {
+				a[i][j] -= f * a[k][j]
+			}
+		}
+	}
+	return det, nil
+}
+
+// Inverse is the inverse of a square m
+func (m Matrix) Inverse() (Matrix, error) {
+	if err := m.square("inverse"); err != nil {
+		return nil, err
+	}
+	return m.Solve(Identity(m.Rows()))
+}
+
+// Solve finds x of m·x = b for a square m. b has a column per right-hand side, a row vector is taken as a column
+func (m Matrix) Solve(b Matrix) (Matrix, error) {
+	if err := m.square("solve"); err != nil {
+		return nil, err
+	}
+	if b.Rows() == 1 && b.Cols() == m.Rows() && m.Rows() > 1 {
+		b = b.Transpose()
+	}
+	if b.Rows() != m.Rows() {
+		return nil, fmt.Errorf("can't solve %s against %s: %d rows against %d", m.Shape(), b.Shape(), m.Rows(), b.Rows())
+	}
+
+	a, x := m.clone(), b.clone()
+	tiny := float64(len(a)) * epsilon * a.maxAbs()
+	for k := range a {
+		p := pivot(a, k)
+		if math.Abs(a[p][k]) <= tiny {
+			return nil, ErrSingular
+		}
+		a[p], a[k] = a[k], a[p]
+		x[p], x[k] = x[k], x[p]
+
+		for i := k + 1; i < len(a); i++ {
+			f := a[i][k] / a[k][k]
+			for j := k; j < len(a); j++ {
+				a[i][j] -= f * a[k][j]
+			}
+			for j := range x[i] {
+				x[i][j] -= f * x[k][j]
+			}
+		}
+	}
+
+	// back substitution of the upper triangular a
+	for k := len(a) - 1; k >= 0; k-- {
+		for j := range x[k] {
+			s := x[k][j]
+			for i := k + 1; i < len(a); i++ {
+				s -= a[k][i] * x[i][j]
+			}
+			x[k][j] = s / a[k][k]
+		}
+	}
+	return x, nil
+}
+
+// Dot is the dot product of two vectors of the same length, either rows or columns
+func Dot(a, b Matrix) (float64, error) {
+	u, v, err := vectors(a, b, "dot product")
+	if err != nil {
+		return 0, err
+	}
+
+	var dot float64
+	for i := range u {
+		dot += u[i] * v[i]
+	}
+	return dot, nil
+}
+
+// Cross is the cross product of two vectors of length 3, shaped like a
+func Cross(a, b Matrix) (Matrix, error) {
+	u, v, err := vectors(a, b, "cross product")
+	if err != nil {
+		return nil, err
+	}
+	if len(u) != 3 {
+		return nil, fmt.Errorf("cross product of vectors of length %d, it needs length 3", len(u))
+	}
+
+	c := Matrix{{u[1]*v[2] - u[2]*v[1], u[2]*v[0] - u[0]*v[2], u[0]*v[1] - u[1]*v[0]}}
+	if a.Cols() == 1 {
+		return c.Transpose(), nil
+	}
+	return c, nil
+}
+
+// Norm is the norm of the kind 1, 2, inf or fro. a vector has the norms of its elements, a matrix has the
+// induced norms: the largest column sum, the largest singular value and the largest row sum
+func (m Matrix) Norm(kind string) (float64, error) {
+	if m.IsVector() {
+		v := m.elements()
+		switch kind {
+		case Norm1:
+			return sum(v, math.Abs), nil
+		case Norm2, NormFrobenius:
+			return hypot(v), nil
+		case NormInf:
+			return maxOf(v, math.Abs), nil
+		}
+		return 0, fmt.Errorf("unknown norm %q: use 1, 2, inf or fro", kind)
+	}
+
+	switch kind {
+	case Norm1:
+		return m.Transpose().Norm(NormInf)
+	case Norm2:
+		return math.Sqrt(maxOf(m.Transpose().mustMul(m).eigenvalues(), math.Abs)), nil
+	case NormInf:
+		var largest float64
+		for _, row := range m {
+			largest = math.Max(largest, sum(row, math.Abs))
+		}
+		return largest, nil
+	case NormFrobenius:
+		return hypot(m.elements()), nil
+	}
+	return 0, fmt.Errorf("unknown norm %q: use 1, 2, inf or fro", kind)
+}
+
+// Format writes m as a grid of rows between brackets, the columns aligned right. a scalar is written alone
+func (m Matrix) Format(format func(float64) string) string {
+	if m.IsScalar() {
+		return format(m[0][0])
+	}
+
+	cells := make([][]string, m.Rows())
+	widths := make([]int, m.Cols())
+	for i, row := range m {
+		cells[i] = make([]string, len(row))
+		for j, v := range row {
+			cells[i][j] = format(v)
+			widths[j] = max(widths[j], utf8.RuneCountInString(cells[i][j]))
+		}
+	}
+
+	lines := make([]string, len(cells))
+	for i, row := range cells {
+		for j, cell := range row {
+			row[j] = strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell)) + cell
+		}
+		lines[i] = "[ " + strings.Join(row, "  ") + " ]"
+	}
+	return strings.Join(lines, "\n")
+}
+
+const epsilon = 0x1p-52
+
+func (m Matrix) elementwise(n Matrix, name string, f func(a, b float64) float64) (Matrix, error) {
+	switch {
+	case n.IsScalar():
+		n = full(m.Rows(), m.Cols(), n[0][0])
+	case m.IsScalar():
+		m = full(n.Rows(), n.Cols(), m[0][0])
+	case m.Rows() != n.Rows() || m.Cols() != n.Cols():
+		return nil, fmt.Errorf("can't %s %s and %s", name, m.Shape(), n.Shape())
+	}
+
+	r := zeros(m.Rows(), m.Cols())
+	for i := range r {
+		for j := range r[i] {
+			r[i][j] = f(m[i][j], n[i][j])
+		}
+	}
+	return r, nil
+}
+
+func (m Matrix) square(name string) error {
+	if m.Rows() != m.Cols() {
+		return fmt.Errorf("%s of %s: the matrix isn't square", name, m.Shape())
+	}
+	return nil
+}
+
+func (m Matrix) clone() Matrix {
+	c := make(Matrix, len(m))
+	for i, row := range m {
+		c[i] = append([]float64{}, row...)
+	}
+	return c
+}
+
+func (m Matrix) elements() []float64 {
+	var e []float64
+	for _, row := range m {
+		e = append(e, row...)
+	}
+	return e
+}
+
+func (m Matrix) maxAbs() float64 {
+	return maxOf(m.elements(), math.Abs)
+}
+
+// mustMul multiplies matrices known to have matching shapes
+func (m Matrix) mustMul(n Matrix) Matrix {
+	p, _ := m.Mul(n)
+	return p
+}
+
+// eigenvalues of a symmetric m by Jacobi rotations, which zero the off-diagonal elements one after the other
+func (m Matrix) eigenvalues() []float64 {
+	a := m.clone()
+	n := len(a)
+	for sweep := 0; sweep < 100; sweep++ {
+		var off float64
+		for i := range a {
+			for j := i + 1; j < n; j++ {
+				off += a[i][j] * a[i][j]
+			}
+		}
+		if off <= epsilon*epsilon*a.maxAbs()*a.maxAbs() {
+			break
+		}
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < n; k++ {
+					a[k][p], a[k][q] = c*a[k][p]-s*a[k][q], s*a[k][p]+c*a[k][q]
+				}
+				for k := 0; k < n; k++ {
+					a[p][k], a[q][k] = c*a[p][k]-s*a[q][k], s*a[p][k]+c*a[q][k]
+				}
+			}
+		}
+	}
+
+	values := make([]float64, n)
+	for i := range a {
+		values[i] = a[i][i]
+	}
+	return values
+}
+
+// pivot is the row from k down with the largest element in column k
+func pivot(a Matrix, k int) int {
+	p := k
+	for i := k + 1; i < len(a); i++ {
+		if math.Abs(a[i][k]) > math.Abs(a[p][k]) {
+			p = i
+		}
+	}
+	return p
+}
+
+func vectors(a, b Matrix, name string) ([]float64, []float64, error) {
+	if !a.IsVector() || !b.IsVector() {
+		return nil, nil, fmt.Errorf("%s of %s and %s: both have to be vectors", name, a.Shape(), b.Shape())
+	}
+	u, v := a.elements(), b.elements()
+	if len(u) != len(v) {
+		return nil, nil, fmt.Errorf("%s of vectors of length %d and %d", name, len(u), len(v))
+	}
+	return u, v, nil
+}
+
+func zeros(rows, cols int) Matrix {
+	return full(rows, cols, 0)
+}
+
+func full(rows, cols int, x float64) Matrix {
+	m := make(Matrix, rows)
+	for i := range m {
+		m[i] = make([]float64, cols)
+		for j := range m[i] {
+			m[i][j] = x
+		}
+	}
+	return m
+}
+
+func sum(v []float64, f func(float64) float64) float64 {
+	var s float64
+	for _, x := range v {
+		s += f(x)
+	}
+	return s
+}
+
+func maxOf(v []float64, f func(float64) float64) float64 {
+	var largest float64
+	for _, x := range v {
+		largest = math.Max(largest, f(x))
+	}
+	return largest
+}
+
+func hypot(v []float64) float64 {
+	var h float64
+	for _, x := range v {
+		h = math.Hypot(h, x)
+	}
+	return h
+}
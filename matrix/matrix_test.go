@@ -0,0 +1,155 @@
+package matrix
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New([][]float64{{1, 2}, {3}})
+	assert.EqualError(t, err, "row 2 has 1 elements, expected 2")
+	_, err = New(nil)
+	assert.EqualError(t, err, "empty matrix")
+}
+
+func TestMatrix_Arithmetic(t *testing.T) {
+	a := Matrix{{1, 2}, {3, 4}}
+	b := Matrix{{5, 6}, {7, 8}}
+
+	sum, err := a.Add(b)
+	assert.NoError(t, err)
+	assert.Equal(t, Matrix{{6, 8}, {10, 12}}, sum)
+
+	diff, err := a.Sub(Scalar(1))
+	assert.NoError(t, err)
+	assert.Equal(t, Matrix{{0, 1}, {2, 3}}, diff)
+
+	p, err := a.Mul(b)
+	assert.NoError(t, err)
+	assert.Equal(t, Matrix{{19, 22}, {43, 50}}, p)
+
+	p, err = Scalar(2).Mul(a)
+	assert.NoError(t, err)
+	assert.Equal(t, Matrix{{2, 4}, {6, 8}}, p)
+
+	p, err = a.Mul(Matrix{{1}, {1}})
+	assert.NoError(t, err)
+	assert.Equal(t, Matrix{{3}, {7}}, p)
+
+	_, err = a.Add(Matrix{{1, 2, 3}})
+	assert.EqualError(t, err, "can't add 2×2 and 1×3")
+	_, err = a.Mul(Matrix{{1, 2, 3}})
+	assert.EqualError(t, err, "can't multiply 2×2 by 1×3: 2 columns against 1 rows")
+
+	assert.Equal(t, Matrix{{1, 4}, {2, 5}, {3, 6}}, Matrix{{1, 2, 3}, {4, 5, 6}}.Transpose())
+}
+
+func TestMatrix_Det(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Matrix
+		want float64
+	}{
+		{name: "2×2", m: Matrix{{1, 2}, {3, 4}}, want: -2},
+		{name: "pivoting", m: Matrix{{0, 1}, {1, 0}}, want: -1},
+		{name: "3×3", m: Matrix{{2, 0, 1}, {1, 3, 2}, {1, 1, 2}}, want: 6},
+		{name: "singular", m: Matrix{{1, 2}, {2, 4}}, want: 0},
+		{name: "scalar", m: Scalar(5), want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Det()
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-12)
+		})
+	}
+
+	_, err := Matrix{{1, 2}}.Det()
+	assert.EqualError(t, err, "determinant of 1×2: the matrix isn't square")
+}
+
+func TestMatrix_Solve(t *testing.T) {
+	a := Matrix{{2, 1}, {1, 3}}
+
+	x, err := a.Solve(Matrix{{3}, {5}})
+	assert.NoError(t, err)
+	assertMatrix(t, Matrix{{0.8}, {1.4}}, x)
+
+	// a row vector is taken as a column
+	x, err = a.Solve(Matrix{{3, 5}})
+	assert.NoError(t, err)
+	assertMatrix(t, Matrix{{0.8}, {1.4}}, x)
+
+	inv, err := a.Inverse()
+	assert.NoError(t, err)
+	assertMatrix(t, Matrix{{0.6, -0.2}, {-0.2, 0.4}}, inv)
+
+	_, err = Matrix{{1, 2}, {2, 4}}.Inverse()
+	assert.ErrorIs(t, err, ErrSingular)
+	_, err = a.Solve(Matrix{{1}, {2}, {3}})
+	assert.EqualError(t, err, "can't solve 2×2 against 3×1: 2 rows against 3")
+}
+
+func TestVectors(t *testing.T) {
+	dot, err := Dot(Matrix{{1, 2, 3}}, Matrix{{4}, {5}, {6}})
+	assert.NoError(t, err)
+	assert.Equal(t, 32.0, dot)
+
+	c, err := Cross(Matrix{{1, 0, 0}}, Matrix{{0, 1, 0}})
+	assert.NoError(t, err)
+	assert.Equal(t, Matrix{{0, 0, 1}}, c)
+
+	_, err = Cross(Matrix{{1, 2}}, Matrix{{3, 4}})
+	assert.EqualError(t, err, "cross product of vectors of length 2, it needs length 3")
+	_, err = Dot(Matrix{{1, 2}}, Matrix{{1, 2, 3}})
+	assert.EqualError(t, err, "dot product of vectors of length 2 and 3")
+}
+
+func TestMatrix_Norm(t *testing.T) {
+	v := Matrix{{3, -4}}
+	m := Matrix{{1, -2}, {3, 4}}
+
+	tests := []struct {
+		name string
+		m    Matrix
+		kind string
+		want float64
+	}{
+		{name: "vector 1", m: v, kind: Norm1, want: 7},
+		{name: "vector 2", m: v, kind: Norm2, want: 5},
+		{name: "vector inf", m: v, kind: NormInf, want: 4},
+		{name: "matrix 1", m: m, kind: Norm1, want: 6},
+		{name: "matrix inf", m: m, kind: NormInf, want: 7},
+		{name: "matrix fro", m: m, kind: NormFrobenius, want: math.Sqrt(30)},
+		{name: "matrix 2", m: Matrix{{3, 0}, {4, 5}}, kind: Norm2, want: math.Sqrt(45)},
+		{name: "diagonal 2", m: Matrix{{-7, 0}, {0, 2}}, kind: Norm2, want: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Norm(tt.kind)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-12)
+		})
+	}
+
+	_, err := m.Norm("max")
+	assert.EqualError(t, err, `unknown norm "max": use 1, 2, inf or fro`)
+}
+
+func TestMatrix_Format(t *testing.T) {
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', 1, 64) }
+
+	assert.Equal(t, "[   1.0  -2.0 ]\n[ 100.0   4.0 ]", Matrix{{1, -2}, {100, 4}}.Format(format))
+	assert.Equal(t, "3.0", Scalar(3).Format(format))
+}
+
+func assertMatrix(t *testing.T, want, got Matrix) {
+	t.Helper()
+	assert.Equal(t, want.Shape(), got.Shape())
+	for i := range want {
+		assert.InDeltaSlice(t, want[i], got[i], 1e-12)
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_calculatorHandler_Handle_Matrix(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "enter with current",
+			commands: []string{"add 5", "mode matrix"},
+			want:     "mode matrix: 1×1",
+		},
+		{
+			name:     "set a matrix",
+			commands: []string{"mode matrix", "add [1 2; 3 4]"},
+			want:     "[ 1.00  2.00 ]\n[ 3.00  4.00 ]",
+		},
+		{
+			name:     "aligned columns",
+			commands: []string{"mode matrix", "add [1 -2; 100 4]"},
+			want:     "[   1.00  -2.00 ]\n[ 100.00   4.00 ]",
+		},
+		{
+			name:     "commas",
+			commands: []string{"mode matrix", "add [1, 2, 3]"},
+			want:     "[ 1.00  2.00  3.00 ]",
+		},
+		{
+			name:     "matrix product",
+			commands: []string{"mode matrix", "add [1 2; 3 4]", "multiply [5 6; 7 8]"},
+			want:     "[ 19.00  22.00 ]\n[ 43.00  50.00 ]",
+		},
+		{
+			name:     "scalar product",
+			commands: []string{"mode matrix", "add [1 2; 3 4]", "multiply 2", "subtract 1"},
+			want:     "[ 1.00  3.00 ]\n[ 5.00  7.00 ]",
+		},
+		{
+			name:     "transpose",
+			commands: []string{"mode matrix", "add [1 2 3]", "transpose"},
+			want:     "[ 1.00 ]\n[ 2.00 ]\n[ 3.00 ]",
+		},
+		{
+			name:     "determinant",
+			commands: []string{"mode matrix", "add [1 2; 3 4]", "det"},
+			want:     "-2.00",
+		},
+		{
+			name:     "inverse",
+			commands: []string{"mode matrix", "format auto", "add [2 1; 1 3]", "inverse"},
+			want:     "[  0.6  -0.2 ]\n[ -0.2   0.4 ]",
+		},
+		{
+			name:     "solve",
+			commands: []string{"mode matrix", "add [2 1; 1 3]", "solve [3; 5]"},
+			want:     "[ 0.80 ]\n[ 1.40 ]",
+		},
+		{
+			name:     "dot product",
+			commands: []string{"mode matrix", "add [1 2 3]", "dot [4 5 6]"},
+			want:     "32.00",
+		},
+		{
+			name:     "cross product",
+			commands: []string{"mode matrix", "add [1 0 0]", "cross [0 1 0]"},
+			want:     "[ 0.00  0.00  1.00 ]",
+		},
+		{
+			name:     "norm",
+			commands: []string{"mode matrix", "add [3 4]", "norm"},
+			want:     "5.00",
+		},
+		{
+			name:     "infinity norm",
+			commands: []string{"mode matrix", "add [1 -2; 3 4]", "norm inf"},
+			want:     "7.00",
+		},
+		{
+			name:     "elements as literals",
+			commands: []string{"mode matrix", "add [1/2 0x10; 1e1 2k]"},
+			want:     "[  0.50    16.00 ]\n[ 10.00  2000.00 ]",
+		},
+		{
+			name:     "cancel",
+			commands: []string{"mode matrix", "add [1 2]", "cancel"},
+			want:     "0.00",
+		},
+		{
+			name:     "current goes to matrix mode",
+			commands: []string{"add 5", "mode matrix", "add [1 2]", "cancel", "mode std", "add 3", "mode matrix", "multiply 2"},
+			want:     "6.00",
+		},
+		{
+			name:     "scalar goes back to current",
+			commands: []string{"mode matrix", "add [1 2; 3 4]", "det", "mode std", "add 1"},
+			want:     "-1.00",
+		},
+		{
+			name:     "matrix leaves current alone",
+			commands: []string{"add 5", "mode matrix", "add [1 2]", "mode std", "add 1"},
+			want:     "6.00",
+		},
+		{
+			name:     "kept across modes",
+			commands: []string{"mode matrix", "add [1 2]", "mode std", "mode matrix"},
+			want:     "mode matrix: 1×2",
+		},
+		{
+			name:     "ragged rows",
+			commands: []string{"mode matrix", "add [1 2; 3]"},
+			wantErr:  `invalid matrix "[1 2; 3]": row 2 has 1 elements, expected 2`,
+		},
+		{
+			name:     "missing bracket",
+			commands: []string{"mode matrix", "add [1 2"},
+			wantErr:  `invalid matrix "[1 2": missing ]`,
+		},
+		{
+			name:     "mismatched shapes",
+			commands: []string{"mode matrix", "add [1 2]", "add [1 2 3]"},
+			wantErr:  "can't add 1×2 and 1×3",
+		},
+		{
+			name:     "singular",
+			commands: []string{"mode matrix", "add [1 2; 2 4]", "inverse"},
+			wantErr:  "matrix is singular",
+		},
+		{
+			name:     "divide by a matrix",
+			commands: []string{"mode matrix", "add [1 2]", "divide [1 2]"},
+			wantErr:  "can't divide by a matrix: use inverse or solve",
+		},
+		{
+			name:     "unsupported command",
+			commands: []string{"mode matrix", "sqrt"},
+			wantErr:  "sqrt is not supported in matrix mode",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleAll(t, tt.commands)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
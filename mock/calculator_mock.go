@@ -7,6 +7,9 @@ package mock_calculator
 import (
 	gomock "github.com/golang/mock/gomock"
 	calculator "gitlab.com/atthoriq/calculator-project/calculator"
+	interval "gitlab.com/atthoriq/calculator-project/interval"
+	sigfig "gitlab.com/atthoriq/calculator-project/sigfig"
+	units "gitlab.com/atthoriq/calculator-project/units"
 	reflect "reflect"
 )
 
@@ -159,6 +162,21 @@ func (mr *MockNewCalculatorMockRecorder) Cancel() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockNewCalculator)(nil).Cancel))
 }
 
+// Invert mocks base method
+func (m *MockNewCalculator) Invert() (calculator.NewCalculator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invert")
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invert indicates an expected call of Invert
+func (mr *MockNewCalculatorMockRecorder) Invert() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invert", reflect.TypeOf((*MockNewCalculator)(nil).Invert))
+}
+
 // GetResult mocks base method
 func (m *MockNewCalculator) GetResult() float64 {
 	m.ctrl.T.Helper()
@@ -172,3 +190,593 @@ func (mr *MockNewCalculatorMockRecorder) GetResult() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResult", reflect.TypeOf((*MockNewCalculator)(nil).GetResult))
 }
+
+// GetFormula mocks base method
+func (m *MockNewCalculator) GetFormula() calculator.Expression {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFormula")
+	ret0, _ := ret[0].(calculator.Expression)
+	return ret0
+}
+
+// GetFormula indicates an expected call of GetFormula
+func (mr *MockNewCalculatorMockRecorder) GetFormula() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFormula", reflect.TypeOf((*MockNewCalculator)(nil).GetFormula))
+}
+
+// GetHistory mocks base method
+func (m *MockNewCalculator) GetHistory() []calculator.Step {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory")
+	ret0, _ := ret[0].([]calculator.Step)
+	return ret0
+}
+
+// GetHistory indicates an expected call of GetHistory
+func (mr *MockNewCalculatorMockRecorder) GetHistory() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockNewCalculator)(nil).GetHistory))
+}
+
+// SetPrecision mocks base method
+func (m *MockNewCalculator) SetPrecision(p calculator.Precision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrecision", p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrecision indicates an expected call of SetPrecision
+func (mr *MockNewCalculatorMockRecorder) SetPrecision(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrecision", reflect.TypeOf((*MockNewCalculator)(nil).SetPrecision), p)
+}
+
+// GetPrecision mocks base method
+func (m *MockNewCalculator) GetPrecision() calculator.Precision {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrecision")
+	ret0, _ := ret[0].(calculator.Precision)
+	return ret0
+}
+
+// GetPrecision indicates an expected call of GetPrecision
+func (mr *MockNewCalculatorMockRecorder) GetPrecision() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrecision", reflect.TypeOf((*MockNewCalculator)(nil).GetPrecision))
+}
+
+// AddPercent mocks base method
+func (m *MockNewCalculator) AddPercent(p float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPercent", p)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// AddPercent indicates an expected call of AddPercent
+func (mr *MockNewCalculatorMockRecorder) AddPercent(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPercent", reflect.TypeOf((*MockNewCalculator)(nil).AddPercent), p)
+}
+
+// SubtractPercent mocks base method
+func (m *MockNewCalculator) SubtractPercent(p float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubtractPercent", p)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// SubtractPercent indicates an expected call of SubtractPercent
+func (mr *MockNewCalculatorMockRecorder) SubtractPercent(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubtractPercent", reflect.TypeOf((*MockNewCalculator)(nil).SubtractPercent), p)
+}
+
+// PercentOf mocks base method
+func (m *MockNewCalculator) PercentOf(p float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PercentOf", p)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// PercentOf indicates an expected call of PercentOf
+func (mr *MockNewCalculatorMockRecorder) PercentOf(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PercentOf", reflect.TypeOf((*MockNewCalculator)(nil).PercentOf), p)
+}
+
+// PercentChange mocks base method
+func (m *MockNewCalculator) PercentChange(x float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PercentChange", x)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// PercentChange indicates an expected call of PercentChange
+func (mr *MockNewCalculatorMockRecorder) PercentChange(x interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PercentChange", reflect.TypeOf((*MockNewCalculator)(nil).PercentChange), x)
+}
+
+// Markup mocks base method
+func (m *MockNewCalculator) Markup(p float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Markup", p)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// Markup indicates an expected call of Markup
+func (mr *MockNewCalculatorMockRecorder) Markup(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Markup", reflect.TypeOf((*MockNewCalculator)(nil).Markup), p)
+}
+
+// Margin mocks base method
+func (m *MockNewCalculator) Margin(p float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Margin", p)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// Margin indicates an expected call of Margin
+func (mr *MockNewCalculatorMockRecorder) Margin(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Margin", reflect.TypeOf((*MockNewCalculator)(nil).Margin), p)
+}
+
+// Discount mocks base method
+func (m *MockNewCalculator) Discount(p float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Discount", p)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// Discount indicates an expected call of Discount
+func (mr *MockNewCalculatorMockRecorder) Discount(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Discount", reflect.TypeOf((*MockNewCalculator)(nil).Discount), p)
+}
+
+// Push mocks base method
+func (m *MockNewCalculator) Push(x float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Push", x)
+}
+
+// Push indicates an expected call of Push
+func (mr *MockNewCalculatorMockRecorder) Push(x interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockNewCalculator)(nil).Push), x)
+}
+
+// GetData mocks base method
+func (m *MockNewCalculator) GetData() []float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetData")
+	ret0, _ := ret[0].([]float64)
+	return ret0
+}
+
+// GetData indicates an expected call of GetData
+func (mr *MockNewCalculatorMockRecorder) GetData() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetData", reflect.TypeOf((*MockNewCalculator)(nil).GetData))
+}
+
+// ClearData mocks base method
+func (m *MockNewCalculator) ClearData() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClearData")
+}
+
+// ClearData indicates an expected call of ClearData
+func (mr *MockNewCalculatorMockRecorder) ClearData() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearData", reflect.TypeOf((*MockNewCalculator)(nil).ClearData))
+}
+
+// PushPoint mocks base method
+func (m *MockNewCalculator) PushPoint(x float64, y float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PushPoint", x, y)
+}
+
+// PushPoint indicates an expected call of PushPoint
+func (mr *MockNewCalculatorMockRecorder) PushPoint(x interface{}, y interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushPoint", reflect.TypeOf((*MockNewCalculator)(nil).PushPoint), x, y)
+}
+
+// GetPoints mocks base method
+func (m *MockNewCalculator) GetPoints() []calculator.Point {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPoints")
+	ret0, _ := ret[0].([]calculator.Point)
+	return ret0
+}
+
+// GetPoints indicates an expected call of GetPoints
+func (mr *MockNewCalculatorMockRecorder) GetPoints() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoints", reflect.TypeOf((*MockNewCalculator)(nil).GetPoints))
+}
+
+// GCD mocks base method
+func (m *MockNewCalculator) GCD(n float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GCD", n)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// GCD indicates an expected call of GCD
+func (mr *MockNewCalculatorMockRecorder) GCD(n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GCD", reflect.TypeOf((*MockNewCalculator)(nil).GCD), n)
+}
+
+// LCM mocks base method
+func (m *MockNewCalculator) LCM(n float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LCM", n)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// LCM indicates an expected call of LCM
+func (mr *MockNewCalculatorMockRecorder) LCM(n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LCM", reflect.TypeOf((*MockNewCalculator)(nil).LCM), n)
+}
+
+// NextPrime mocks base method
+func (m *MockNewCalculator) NextPrime() calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextPrime")
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// NextPrime indicates an expected call of NextPrime
+func (mr *MockNewCalculatorMockRecorder) NextPrime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextPrime", reflect.TypeOf((*MockNewCalculator)(nil).NextPrime))
+}
+
+// ModPow mocks base method
+func (m *MockNewCalculator) ModPow(e float64, mod float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModPow", e, mod)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// ModPow indicates an expected call of ModPow
+func (mr *MockNewCalculatorMockRecorder) ModPow(e interface{}, mod interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModPow", reflect.TypeOf((*MockNewCalculator)(nil).ModPow), e, mod)
+}
+
+// ModInv mocks base method
+func (m *MockNewCalculator) ModInv(mod float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModInv", mod)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// ModInv indicates an expected call of ModInv
+func (mr *MockNewCalculatorMockRecorder) ModInv(mod interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModInv", reflect.TypeOf((*MockNewCalculator)(nil).ModInv), mod)
+}
+
+// Factorial mocks base method
+func (m *MockNewCalculator) Factorial() calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Factorial")
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// Factorial indicates an expected call of Factorial
+func (mr *MockNewCalculatorMockRecorder) Factorial() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Factorial", reflect.TypeOf((*MockNewCalculator)(nil).Factorial))
+}
+
+// Gamma mocks base method
+func (m *MockNewCalculator) Gamma() calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gamma")
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// Gamma indicates an expected call of Gamma
+func (mr *MockNewCalculatorMockRecorder) Gamma() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gamma", reflect.TypeOf((*MockNewCalculator)(nil).Gamma))
+}
+
+// LogGamma mocks base method
+func (m *MockNewCalculator) LogGamma() calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogGamma")
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// LogGamma indicates an expected call of LogGamma
+func (mr *MockNewCalculatorMockRecorder) LogGamma() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogGamma", reflect.TypeOf((*MockNewCalculator)(nil).LogGamma))
+}
+
+// Choose mocks base method
+func (m *MockNewCalculator) Choose(k float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Choose", k)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// Choose indicates an expected call of Choose
+func (mr *MockNewCalculatorMockRecorder) Choose(k interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Choose", reflect.TypeOf((*MockNewCalculator)(nil).Choose), k)
+}
+
+// Permutations mocks base method
+func (m *MockNewCalculator) Permutations(k float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Permutations", k)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// Permutations indicates an expected call of Permutations
+func (mr *MockNewCalculatorMockRecorder) Permutations(k interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Permutations", reflect.TypeOf((*MockNewCalculator)(nil).Permutations), k)
+}
+
+// Binomial mocks base method
+func (m *MockNewCalculator) Binomial(k float64, p float64) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Binomial", k, p)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// Binomial indicates an expected call of Binomial
+func (mr *MockNewCalculatorMockRecorder) Binomial(k interface{}, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Binomial", reflect.TypeOf((*MockNewCalculator)(nil).Binomial), k, p)
+}
+
+// SetUnit mocks base method
+func (m *MockNewCalculator) SetUnit(u units.Unit) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetUnit", u)
+}
+
+// SetUnit indicates an expected call of SetUnit
+func (mr *MockNewCalculatorMockRecorder) SetUnit(u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUnit", reflect.TypeOf((*MockNewCalculator)(nil).SetUnit), u)
+}
+
+// GetUnit mocks base method
+func (m *MockNewCalculator) GetUnit() units.Unit {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnit")
+	ret0, _ := ret[0].(units.Unit)
+	return ret0
+}
+
+// GetUnit indicates an expected call of GetUnit
+func (mr *MockNewCalculatorMockRecorder) GetUnit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnit", reflect.TypeOf((*MockNewCalculator)(nil).GetUnit))
+}
+
+// AddUnit mocks base method
+func (m *MockNewCalculator) AddUnit(a float64, u units.Unit) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUnit", a, u)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// AddUnit indicates an expected call of AddUnit
+func (mr *MockNewCalculatorMockRecorder) AddUnit(a interface{}, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUnit", reflect.TypeOf((*MockNewCalculator)(nil).AddUnit), a, u)
+}
+
+// SubtractUnit mocks base method
+func (m *MockNewCalculator) SubtractUnit(a float64, u units.Unit) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubtractUnit", a, u)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// SubtractUnit indicates an expected call of SubtractUnit
+func (mr *MockNewCalculatorMockRecorder) SubtractUnit(a interface{}, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubtractUnit", reflect.TypeOf((*MockNewCalculator)(nil).SubtractUnit), a, u)
+}
+
+// MultiplyUnit mocks base method
+func (m *MockNewCalculator) MultiplyUnit(a float64, u units.Unit) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MultiplyUnit", a, u)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// MultiplyUnit indicates an expected call of MultiplyUnit
+func (mr *MockNewCalculatorMockRecorder) MultiplyUnit(a interface{}, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MultiplyUnit", reflect.TypeOf((*MockNewCalculator)(nil).MultiplyUnit), a, u)
+}
+
+// DivideUnit mocks base method
+func (m *MockNewCalculator) DivideUnit(a float64, u units.Unit) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DivideUnit", a, u)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// DivideUnit indicates an expected call of DivideUnit
+func (mr *MockNewCalculatorMockRecorder) DivideUnit(a interface{}, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DivideUnit", reflect.TypeOf((*MockNewCalculator)(nil).DivideUnit), a, u)
+}
+
+// Convert mocks base method
+func (m *MockNewCalculator) Convert(a, b float64, u units.Unit) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Convert", a, b, u)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// Convert indicates an expected call of Convert
+func (mr *MockNewCalculatorMockRecorder) Convert(a interface{}, b interface{}, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Convert", reflect.TypeOf((*MockNewCalculator)(nil).Convert), a, b, u)
+}
+
+// SetCurrency mocks base method
+func (m *MockNewCalculator) SetCurrency(code string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetCurrency", code)
+}
+
+// SetCurrency indicates an expected call of SetCurrency
+func (mr *MockNewCalculatorMockRecorder) SetCurrency(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrency", reflect.TypeOf((*MockNewCalculator)(nil).SetCurrency), code)
+}
+
+// GetCurrency mocks base method
+func (m *MockNewCalculator) GetCurrency() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrency")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetCurrency indicates an expected call of GetCurrency
+func (mr *MockNewCalculatorMockRecorder) GetCurrency() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrency", reflect.TypeOf((*MockNewCalculator)(nil).GetCurrency))
+}
+
+// Exchange mocks base method
+func (m *MockNewCalculator) Exchange(rate float64, code string) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", rate, code)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// Exchange indicates an expected call of Exchange
+func (mr *MockNewCalculatorMockRecorder) Exchange(rate interface{}, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockNewCalculator)(nil).Exchange), rate, code)
+}
+
+// GetBounds mocks base method
+func (m *MockNewCalculator) GetBounds() interval.Interval {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBounds")
+	ret0, _ := ret[0].(interval.Interval)
+	return ret0
+}
+
+// GetBounds indicates an expected call of GetBounds
+func (mr *MockNewCalculatorMockRecorder) GetBounds() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBounds", reflect.TypeOf((*MockNewCalculator)(nil).GetBounds))
+}
+
+// Within mocks base method
+func (m *MockNewCalculator) Within(x interval.Interval) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Within", x)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// Within indicates an expected call of Within
+func (mr *MockNewCalculatorMockRecorder) Within(x interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Within", reflect.TypeOf((*MockNewCalculator)(nil).Within), x)
+}
+
+// GetFigures mocks base method
+func (m *MockNewCalculator) GetFigures() sigfig.Figures {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFigures")
+	ret0, _ := ret[0].(sigfig.Figures)
+	return ret0
+}
+
+// GetFigures indicates an expected call of GetFigures
+func (mr *MockNewCalculatorMockRecorder) GetFigures() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFigures", reflect.TypeOf((*MockNewCalculator)(nil).GetFigures))
+}
+
+// Significant mocks base method
+func (m *MockNewCalculator) Significant(f sigfig.Figures) calculator.NewCalculator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Significant", f)
+	ret0, _ := ret[0].(calculator.NewCalculator)
+	return ret0
+}
+
+// Significant indicates an expected call of Significant
+func (mr *MockNewCalculatorMockRecorder) Significant(f interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Significant", reflect.TypeOf((*MockNewCalculator)(nil).Significant), f)
+}
+
+// SetCompensated mocks base method
+func (m *MockNewCalculator) SetCompensated(on bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetCompensated", on)
+}
+
+// SetCompensated indicates an expected call of SetCompensated
+func (mr *MockNewCalculatorMockRecorder) SetCompensated(on interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCompensated", reflect.TypeOf((*MockNewCalculator)(nil).SetCompensated), on)
+}
+
+// GetAccuracy mocks base method
+func (m *MockNewCalculator) GetAccuracy() calculator.Accuracy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccuracy")
+	ret0, _ := ret[0].(calculator.Accuracy)
+	return ret0
+}
+
+// GetAccuracy indicates an expected call of GetAccuracy
+func (mr *MockNewCalculatorMockRecorder) GetAccuracy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccuracy", reflect.TypeOf((*MockNewCalculator)(nil).GetAccuracy))
+}
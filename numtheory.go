@@ -0,0 +1,114 @@
+package main
+
+// handler of the number theory commands. they work on integer current and integer arguments, and fail
+// with a clear error instead of the NaN the calculator gives for anything else.
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/atthoriq/calculator-project/calculator"
+	"gitlab.com/atthoriq/calculator-project/numtheory"
+)
+
+const (
+	gcd       = "gcd"
+	lcm       = "lcm"
+	isPrime   = "isprime"
+	factor    = "factor"
+	nextPrime = "nextprime"
+	modPow    = "modpow"
+	modInv    = "modinv"
+)
+
+// handleNumberTheory handles the number theory commands
+func (ch *calculatorHandler) handleNumberTheory(op string, args []string) (string, error) {
+	x, err := numtheory.FromFloat(ch.calculator.GetResult())
+	if err != nil {
+		return "", fmt.Errorf("current %w", err)
+	}
+
+	arity := map[string]int{gcd: 1, lcm: 1, isPrime: 0, factor: 0, nextPrime: 0, modPow: 2, modInv: 1}[op]
+	n, err := ch.parseIntegers(args, arity)
+	if err != nil {
+		return "", err
+	}
+
+	var calc calculator.NewCalculator
+	switch op {
+	case isPrime:
+		if numtheory.IsPrime(x) {
+			return fmt.Sprintf("%d is prime", x), nil
+		}
+		return fmt.Sprintf("%d is not prime", x), nil
+	case factor:
+		factors, err := numtheory.Factor(x)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d = %s", x, formatFactors(factors)), nil
+	case gcd:
+		calc = ch.calculator.GCD(float64(n[0]))
+	case lcm:
+		// the calculator would only give NaN, check it first to tell why
+		if _, err := numtheory.LCM(x, n[0]); err != nil {
+			return "", err
+		}
+		calc = ch.calculator.LCM(float64(n[0]))
+	case nextPrime:
+		calc = ch.calculator.NextPrime()
+	case modPow:
+		if _, err := numtheory.ModPow(x, n[0], n[1]); err != nil {
+			return "", err
+		}
+		calc = ch.calculator.ModPow(float64(n[0]), float64(n[1]))
+	case modInv:
+		if _, err := numtheory.ModInv(x, n[0]); err != nil {
+			return "", err
+		}
+		calc = ch.calculator.ModInv(float64(n[0]))
+	default:
+		return "", errInvalidInput
+	}
+
+	res := calc.GetResult()
+	return ch.formatCurrent(res), nil
+}
+
+// parseIntegers reads exactly count arguments as integers
+func (ch *calculatorHandler) parseIntegers(args []string, count int) ([]int64, error) {
+	values, err := ch.parseValues(args, count, count)
+	if err != nil {
+		return nil, err
+	}
+
+	integers := make([]int64, len(values))
+	for i, v := range values {
+		if integers[i], err = numtheory.FromFloat(v); err != nil {
+			return nil, err
+		}
+	}
+	return integers, nil
+}
+
+// formatFactors prints the prime factors with their multiplicity as power, e.g. 2^3 × 3^2 × 5
+func formatFactors(factors []int64) string {
+	if len(factors) == 0 {
+		return "1"
+	}
+
+	var terms []string
+	for i := 0; i < len(factors); {
+		j := i
+		for j < len(factors) && factors[j] == factors[i] {
+			j++
+		}
+		if j-i == 1 {
+			terms = append(terms, fmt.Sprint(factors[i]))
+		} else {
+			terms = append(terms, fmt.Sprintf("%d^%d", factors[i], j-i))
+		}
+		i = j
+	}
+	return strings.Join(terms, " × ")
+}
@@ -0,0 +1,169 @@
+package numtheory
+
+// number theory on the integers a float64 holds exactly, |n| <= 2^53.
+// products are done on big integers so they never overflow, primality is checked with
+// Baillie-PSW which is exact for 64-bit integers and factors are found with Pollard's rho.
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"sort"
+)
+
+// MaxExact is the largest integer from which every smaller integer is exactly representable as float64
+const MaxExact = 1 << 53
+
+var ErrNotInvertible = errors.New("not invertible")
+
+// FromFloat returns f as integer, it fails when f has a fraction or is too large to be exact
+func FromFloat(f float64) (int64, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+		return 0, fmt.Errorf("%v is not an integer", f)
+	}
+	if math.Abs(f) > MaxExact {
+		return 0, fmt.Errorf("%v is too large to be an exact integer, the limit is 2^53", f)
+	}
+	return int64(f), nil
+}
+
+// GCD is the greatest common divisor of a and b, never negative
+func GCD(a, b int64) int64 {
+	a, b = abs(a), abs(b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// LCM is the least common multiple of a and b, never negative. it fails when it exceeds 2^53
+func LCM(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+
+	lcm := new(big.Int).Mul(big.NewInt(abs(a)/GCD(a, b)), big.NewInt(abs(b)))
+	if lcm.Cmp(big.NewInt(MaxExact)) > 0 {
+		return 0, fmt.Errorf("lcm of %d and %d is too large to be an exact integer", a, b)
+	}
+	return lcm.Int64(), nil
+}
+
+func IsPrime(n int64) bool {
+	// ProbablyPrime is 100% accurate below 2^64
+	return n > 1 && big.NewInt(n).ProbablyPrime(0)
+}
+
+// NextPrime is the smallest prime greater than n
+func NextPrime(n int64) int64 {
+	if n < 2 {
+		return 2
+	}
+	for n++; !IsPrime(n); n++ {
+	}
+	return n
+}
+
+// Factor returns the prime factors of n in ascending order, repeated by their multiplicity.
+// a negative n has -1 as first factor, 1 has none and 0 can't be factored
+func Factor(n int64) ([]int64, error) {
+	if n == 0 {
+		return nil, errors.New("0 has no prime factorization")
+	}
+
+	var factors []int64
+	if n < 0 {
+		factors = append(factors, -1)
+		n = -n
+	}
+	for _, p := range []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37} {
+		for n%p == 0 {
+			factors = append(factors, p)
+			n /= p
+		}
+	}
+
+	primes := factorRho(uint64(n))
+	sort.Slice(primes, func(i, j int) bool { return primes[i] < primes[j] })
+	return append(factors, primes...), nil
+}
+
+// ModPow is b^e mod m in [0, m). a negative e takes the power of the inverse of b
+func ModPow(b, e, m int64) (int64, error) {
+	if m < 1 {
+		return 0, fmt.Errorf("invalid modulus %d: it has to be positive", m)
+	}
+
+	base := big.NewInt(b)
+	if e < 0 {
+		inv, err := ModInv(b, m)
+		if err != nil {
+			return 0, err
+		}
+		base, e = big.NewInt(inv), -e
+	}
+	return new(big.Int).Exp(base.Mod(base, big.NewInt(m)), big.NewInt(e), big.NewInt(m)).Int64(), nil
+}
+
+// ModInv is x in [0, m) with a*x = 1 mod m, it exists when a and m are coprime
+func ModInv(a, m int64) (int64, error) {
+	if m < 1 {
+		return 0, fmt.Errorf("invalid modulus %d: it has to be positive", m)
+	}
+
+	inv := new(big.Int).ModInverse(big.NewInt(a), big.NewInt(m))
+	if inv == nil || m == 1 {
+		return 0, fmt.Errorf("%d mod %d: %w, gcd is %d", a, m, ErrNotInvertible, GCD(a, m))
+	}
+	return inv.Int64(), nil
+}
+
+// factorRho returns the prime factors of n, which has no factor below 41
+func factorRho(n uint64) []int64 {
+	if n == 1 {
+		return nil
+	}
+	if IsPrime(int64(n)) {
+		return []int64{int64(n)}
+	}
+
+	d := rho(n)
+	return append(factorRho(d), factorRho(n/d)...)
+}
+
+// rho returns a non trivial divisor of the composite n with Pollard's rho and Floyd's cycle detection
+func rho(n uint64) uint64 {
+	for c := uint64(1); ; c++ {
+		f := func(x uint64) uint64 { return (mulMod(x, x, n) + c) % n }
+		x, y, d := uint64(2), uint64(2), uint64(1)
+		for d == 1 {
+			x, y = f(x), f(f(y))
+			d = uint64(GCD(int64(diff(x, y)), int64(n)))
+		}
+		if d != n {
+			return d
+		}
+	}
+}
+
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, rem := bits.Div64(hi%m, lo, m)
+	return rem
+}
+
+func diff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
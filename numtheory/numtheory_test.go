@@ -0,0 +1,89 @@
+package numtheory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromFloat(t *testing.T) {
+	n, err := FromFloat(-12)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-12), n)
+
+	_, err = FromFloat(1.5)
+	assert.Error(t, err)
+	_, err = FromFloat(1e17)
+	assert.Error(t, err)
+}
+
+func TestGCDAndLCM(t *testing.T) {
+	assert.Equal(t, int64(6), GCD(12, -18))
+	assert.Equal(t, int64(5), GCD(0, 5))
+
+	lcm, err := LCM(4, -6)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), lcm)
+
+	_, err = LCM(1<<52-1, 1<<52-3)
+	assert.Error(t, err)
+}
+
+func TestPrimes(t *testing.T) {
+	assert.True(t, IsPrime(2))
+	assert.True(t, IsPrime(9007199254740881)) // largest prime below 2^53
+	assert.False(t, IsPrime(1))
+	assert.False(t, IsPrime(-7))
+	assert.False(t, IsPrime(561)) // carmichael number
+
+	assert.Equal(t, int64(2), NextPrime(-5))
+	assert.Equal(t, int64(11), NextPrime(7))
+	assert.Equal(t, int64(101), NextPrime(100))
+}
+
+func TestFactor(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want []int64
+	}{
+		{n: 360, want: []int64{2, 2, 2, 3, 3, 5}},
+		{n: -12, want: []int64{-1, 2, 2, 3}},
+		{n: 1, want: nil},
+		{n: 97, want: []int64{97}},
+		{n: 1000003 * 1000033, want: []int64{1000003, 1000033}},
+		{n: 9007199254740881, want: []int64{9007199254740881}},
+	}
+	for _, tt := range tests {
+		got, err := Factor(tt.n)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, "Factor(%d)", tt.n)
+	}
+
+	_, err := Factor(0)
+	assert.Error(t, err)
+}
+
+func TestModular(t *testing.T) {
+	p, err := ModPow(4, 13, 497)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(445), p)
+
+	p, err = ModPow(-2, 3, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), p)
+
+	p, err = ModPow(3, -1, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), p)
+
+	inv, err := ModInv(3, 11)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), inv)
+
+	_, err = ModInv(6, 9)
+	assert.True(t, errors.Is(err, ErrNotInvertible))
+
+	_, err = ModPow(2, 3, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_calculatorHandler_Handle_NumberTheory(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "gcd",
+			commands: []string{"add 12", "gcd 18"},
+			want:     "6.00",
+		},
+		{
+			name:     "gcd keeps the unit",
+			commands: []string{"add 12 m", "gcd 18"},
+			want:     "6.00 m",
+		},
+		{
+			name:     "lcm",
+			commands: []string{"add 4", "lcm 6"},
+			want:     "12.00",
+		},
+		{
+			name:     "isprime",
+			commands: []string{"add 97", "isprime"},
+			want:     "97 is prime",
+		},
+		{
+			name:     "not prime",
+			commands: []string{"add 91", "isprime"},
+			want:     "91 is not prime",
+		},
+		{
+			name:     "factor",
+			commands: []string{"add 360", "factor"},
+			want:     "360 = 2^3 × 3^2 × 5",
+		},
+		{
+			name:     "factor of a negative number",
+			commands: []string{"subtract 14", "factor"},
+			want:     "-14 = -1 × 2 × 7",
+		},
+		{
+			name:     "nextprime",
+			commands: []string{"add 100", "nextprime"},
+			want:     "101.00",
+		},
+		{
+			name:     "modpow with two arguments",
+			commands: []string{"add 4", "modpow 13 497"},
+			want:     "445.00",
+		},
+		{
+			name:     "modinv",
+			commands: []string{"add 3", "modinv 11"},
+			want:     "4.00",
+		},
+		{
+			name:     "modinv without inverse",
+			commands: []string{"add 6", "modinv 9"},
+			wantErr:  "6 mod 9: not invertible, gcd is 3",
+		},
+		{
+			name:     "current is not an integer",
+			commands: []string{"add 1.5", "gcd 3"},
+			wantErr:  "current 1.5 is not an integer",
+		},
+		{
+			name:     "argument is not an integer",
+			commands: []string{"add 4", "lcm 2.5"},
+			wantErr:  "2.5 is not an integer",
+		},
+		{
+			name:     "modpow with a missing argument",
+			commands: []string{"add 4", "modpow 13"},
+			wantErr:  errInvalidInput.Error(),
+		},
+		{
+			name:     "recorded in history",
+			commands: []string{"add 2", "modpow 2 1000", "repeat 1", "formula"},
+			want:     "modpow(modpow(x + 2, 2, 1000), 2, 1000)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleAll(t, tt.commands)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
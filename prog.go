@@ -0,0 +1,196 @@
+package main
+
+// handler of the programmer mode. the running value is a programmer.Register instead of the calculator,
+// and every result is shown in dec, hex, oct and bin at once.
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"gitlab.com/atthoriq/calculator-project/programmer"
+)
+
+const (
+	and      = "and"
+	or       = "or"
+	xor      = "xor"
+	not      = "not"
+	shl      = "shl"
+	shr      = "shr"
+	rol      = "rol"
+	ror      = "ror"
+	width    = "width"
+	signed   = "signed"
+	unsigned = "unsigned"
+	overflow = "overflow"
+)
+
+// handleProgrammer handles the commands of the programmer mode
+func (ch *calculatorHandler) handleProgrammer(op string, args []string) (string, error) {
+	r := ch.register
+
+	switch op {
+	case add, subtract, multiply, divide, and, or, xor:
+		v, err := ch.parseInteger(args)
+		if err != nil {
+			return "", err
+		}
+
+		switch op {
+		case add:
+			err = r.Add(v)
+		case subtract:
+			err = r.Subtract(v)
+		case multiply:
+			err = r.Multiply(v)
+		case divide:
+			err = r.Divide(v)
+		case and:
+			r.And(v)
+		case or:
+			r.Or(v)
+		case xor:
+			r.Xor(v)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		return r.String(), nil
+	case shl, shr, rol, ror:
+		v, err := ch.parseInteger(args)
+		if err != nil {
+			return "", err
+		}
+		if !v.IsInt64() || v.Int64() > math.MaxInt32 || v.Int64() < math.MinInt32 {
+			return "", fmt.Errorf("invalid shift %s", v)
+		}
+
+		n := int(v.Int64())
+		switch op {
+		case shl:
+			err = r.Shl(n)
+		case shr:
+			err = r.Shr(n)
+		case rol:
+			r.Rol(n)
+		case ror:
+			r.Ror(n)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		return r.String(), nil
+	case neg, not, cancel:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
+		var err error
+		switch op {
+		case neg:
+			err = r.Neg()
+		case not:
+			r.Not()
+		case cancel:
+			err = r.Set(new(big.Int))
+		}
+		if err != nil {
+			return "", err
+		}
+
+		return r.String(), nil
+	case width:
+		if len(args) != 1 {
+			return "", errInvalidInput
+		}
+
+		w, err := strconv.Atoi(args[0])
+		if err != nil {
+			return "", errInvalidInput
+		}
+		if err := r.SetWidth(w); err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%s: %s", r.Kind(), r), nil
+	case signed, unsigned:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
+		r.SetSigned(op == signed)
+		return fmt.Sprintf("%s: %s", r.Kind(), r), nil
+	case overflow:
+		if len(args) == 0 {
+			return string(r.Overflow()), nil
+		}
+		if len(args) > 1 {
+			return "", errInvalidInput
+		}
+
+		if err := r.SetOverflow(programmer.Overflow(args[0])); err != nil {
+			return "", err
+		}
+
+		return "overflow " + args[0], nil
+	default:
+		return "", fmt.Errorf("%s is not supported in programmer mode", op)
+	}
+}
+
+// parseInteger reads an integer operand. go literals like 0xFF, 0b1010 or 1_000 are read exactly,
+// other literals are accepted as long as they have an integer value, e.g. 3k
+func (ch *calculatorHandler) parseInteger(args []string) (*big.Int, error) {
+	if len(args) != 1 {
+		return nil, errInvalidInput
+	}
+
+	base := 10
+	body := strings.TrimLeft(args[0], "+-")
+	if len(body) > 1 && body[0] == '0' && strings.ContainsRune("xXbBoO", rune(body[1])) {
+		base = 0
+	}
+	if v, ok := new(big.Int).SetString(args[0], base); ok {
+		return v, nil
+	}
+
+	o, err := ch.locale.parseOperand(args)
+	if err != nil {
+		return nil, err
+	}
+	if o.percent || math.IsInf(o.value, 0) || o.value != math.Trunc(o.value) {
+		return nil, fmt.Errorf("%s is not an integer", args[0])
+	}
+
+	v, _ := big.NewFloat(o.value).Int(nil)
+	return v, nil
+}
+
+// enterProgrammer seeds the register with the current value, which has to be an integer
+func (ch *calculatorHandler) enterProgrammer() error {
+	if ch.register == nil {
+		ch.register = programmer.New()
+	}
+
+	current := ch.calculator.GetResult()
+	if math.IsNaN(current) || math.IsInf(current, 0) {
+		return fmt.Errorf("current %v can't be used as integer", current)
+	}
+	if current != math.Trunc(current) {
+		return fmt.Errorf("current %v is not an integer", current)
+	}
+
+	v, _ := big.NewFloat(current).Int(nil)
+	return ch.register.Set(v)
+}
+
+// leaveProgrammer writes the register back to current
+func (ch *calculatorHandler) leaveProgrammer() {
+	v, _ := new(big.Float).SetInt(ch.register.Value()).Float64()
+	ch.setCurrent(v)
+}
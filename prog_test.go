@@ -0,0 +1,84 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_calculatorHandler_Handle_Programmer(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "entering the mode",
+			commands: []string{"add 10", "mode prog"},
+			want:     "mode prog int64: dec 10  hex 0xA  oct 0o12  bin 0b0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_1010",
+		},
+		{
+			name:     "entering the mode with a fraction",
+			commands: []string{"add 10.7", "mode prog"},
+			wantErr:  true,
+		},
+		{
+			name:     "bitwise operations with go literals",
+			commands: []string{"mode prog", "width 8", "add 0b1100", "xor 0xFF"},
+			want:     "dec -13  hex 0xF3  oct 0o363  bin 0b1111_0011",
+		},
+		{
+			name:     "unsigned view of the same bits",
+			commands: []string{"mode prog", "width 8", "subtract 13", "unsigned"},
+			want:     "uint8: dec 243  hex 0xF3  oct 0o363  bin 0b1111_0011",
+		},
+		{
+			name:     "overflow error",
+			commands: []string{"mode prog", "width 8", "overflow error", "add 127", "add 1"},
+			wantErr:  true,
+		},
+		{
+			name:     "si suffixed operand",
+			commands: []string{"mode prog", "width 16", "add 2k", "shr 3"},
+			want:     "dec 250  hex 0xFA  oct 0o372  bin 0b0000_0000_1111_1010",
+		},
+		{
+			name:     "fractional operand",
+			commands: []string{"mode prog", "add 1.5"},
+			wantErr:  true,
+		},
+		{
+			name:     "standard command is not supported",
+			commands: []string{"mode prog", "sqrt"},
+			wantErr:  true,
+		},
+		{
+			name:     "back to standard mode keeps the integer",
+			commands: []string{"add 2", "mode prog", "add 40", "mode std", "add 1"},
+			want:     "43.00",
+		},
+		{
+			name:     "the integer is added to the history",
+			commands: []string{"add 2", "mode prog", "add 40", "mode std", "formula"},
+			want:     "x + 42",
+		},
+		{
+			name:     "entering the mode with NaN",
+			commands: []string{"divide 0", "mode prog"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleAll(t, tt.commands)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("calculatorHandler.Handle() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package programmer
+
+// Register is the running value of the programmer mode, an integer of a fixed width that is either signed or unsigned.
+// the bits are kept as unsigned value masked to the width, signed registers read them as two's complement.
+// arithmetic is done on big integers so an overflow can be detected, then it wraps or fails according to the overflow setting.
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+type Overflow string
+
+const (
+	Wrap  Overflow = "wrap"
+	Error Overflow = "error"
+)
+
+var widths = map[int]bool{8: true, 16: true, 32: true, 64: true}
+
+type Register struct {
+	bits     uint64
+	width    int
+	signed   bool
+	overflow Overflow
+}
+
+// New returns a signed 64-bit register holding 0 which wraps on overflow
+func New() *Register {
+	return &Register{width: 64, signed: true, overflow: Wrap}
+}
+
+func (r *Register) Width() int {
+	return r.width
+}
+
+func (r *Register) Signed() bool {
+	return r.signed
+}
+
+func (r *Register) Overflow() Overflow {
+	return r.overflow
+}
+
+// SetWidth changes the width keeping the value, which has to fit the new width unless the register wraps
+func (r *Register) SetWidth(width int) error {
+	if !widths[width] {
+		return fmt.Errorf("invalid width %d: use 8, 16, 32 or 64", width)
+	}
+
+	value, previous := r.Value(), r.width
+	r.width = width
+	if err := r.Set(value); err != nil {
+		r.width = previous
+		return err
+	}
+	return nil
+}
+
+// SetSigned changes the signedness, keeping the bits as they are
+func (r *Register) SetSigned(signed bool) {
+	r.signed = signed
+}
+
+func (r *Register) SetOverflow(o Overflow) error {
+	if o != Wrap && o != Error {
+		return fmt.Errorf("invalid overflow %q: use wrap or error", o)
+	}
+	r.overflow = o
+	return nil
+}
+
+// Set puts v into the register
+func (r *Register) Set(v *big.Int) error {
+	if !r.fits(v) && r.overflow == Error {
+		return fmt.Errorf("overflow: %s doesn't fit %s", v, r.Kind())
+	}
+
+	// v mod 2^width is the two's complement bits of v
+	mod := new(big.Int).Lsh(big.NewInt(1), uint(r.width))
+	r.bits = new(big.Int).Mod(v, mod).Uint64()
+	return nil
+}
+
+// Value returns the register as integer, interpreting the bits as two's complement when signed
+func (r *Register) Value() *big.Int {
+	v := new(big.Int).SetUint64(r.bits)
+	if r.signed && r.bits>>(r.width-1)&1 == 1 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(r.width)))
+	}
+	return v
+}
+
+func (r *Register) Add(v *big.Int) error {
+	return r.Set(new(big.Int).Add(r.Value(), v))
+}
+
+func (r *Register) Subtract(v *big.Int) error {
+	return r.Set(new(big.Int).Sub(r.Value(), v))
+}
+
+func (r *Register) Multiply(v *big.Int) error {
+	return r.Set(new(big.Int).Mul(r.Value(), v))
+}
+
+// Divide is an integer division truncated toward zero
+func (r *Register) Divide(v *big.Int) error {
+	if v.Sign() == 0 {
+		return errors.New("division by zero")
+	}
+	return r.Set(new(big.Int).Quo(r.Value(), v))
+}
+
+func (r *Register) Neg() error {
+	return r.Set(new(big.Int).Neg(r.Value()))
+}
+
+// And, Or and Xor take the two's complement bits of v in the register width
+func (r *Register) And(v *big.Int) {
+	r.bits &= r.operandBits(v)
+}
+
+func (r *Register) Or(v *big.Int) {
+	r.bits |= r.operandBits(v)
+}
+
+func (r *Register) Xor(v *big.Int) {
+	r.bits ^= r.operandBits(v)
+}
+
+func (r *Register) Not() {
+	r.bits = ^r.bits & r.mask()
+}
+
+// Shl shifts to the left, the bits shifted out are dropped
+func (r *Register) Shl(n int) error {
+	if n < 0 {
+		return errors.New("can't shift by a negative number")
+	}
+	if n >= r.width {
+		r.bits = 0
+		return nil
+	}
+	r.bits = r.bits << n & r.mask()
+	return nil
+}
+
+// Shr shifts to the right, it is an arithmetic shift keeping the sign when the register is signed
+func (r *Register) Shr(n int) error {
+	if n < 0 {
+		return errors.New("can't shift by a negative number")
+	}
+	if n >= r.width {
+		n = r.width
+	}
+
+	negative := r.signed && r.bits>>(r.width-1)&1 == 1
+	if n == r.width {
+		r.bits = 0
+	} else {
+		r.bits >>= n
+	}
+	if negative {
+		// fill the vacated bits with the sign
+		r.bits |= ^(r.mask() >> n) & r.mask()
+	}
+	return nil
+}
+
+// Rol rotates to the left within the register width
+func (r *Register) Rol(n int) {
+	n = ((n % r.width) + r.width) % r.width
+	if r.width == 64 {
+		r.bits = bits.RotateLeft64(r.bits, n)
+		return
+	}
+	r.bits = (r.bits<<n | r.bits>>(r.width-n)) & r.mask()
+}
+
+// Ror rotates to the right within the register width
+func (r *Register) Ror(n int) {
+	r.Rol(-n)
+}
+
+// Dec, Hex, Oct and Bin print the register. hex, oct and bin show the raw bits, as typed with go literals
+func (r *Register) Dec() string {
+	return r.Value().String()
+}
+
+func (r *Register) Hex() string {
+	return fmt.Sprintf("0x%X", r.bits)
+}
+
+func (r *Register) Oct() string {
+	return fmt.Sprintf("0o%o", r.bits)
+}
+
+// Bin prints every bit of the width, grouped by 4
+func (r *Register) Bin() string {
+	digits := fmt.Sprintf("%0*b", r.width, r.bits)
+	groups := make([]string, 0, r.width/4)
+	for i := 0; i < len(digits); i += 4 {
+		groups = append(groups, digits[i:i+4])
+	}
+	return "0b" + strings.Join(groups, "_")
+}
+
+func (r *Register) String() string {
+	return fmt.Sprintf("dec %s  hex %s  oct %s  bin %s", r.Dec(), r.Hex(), r.Oct(), r.Bin())
+}
+
+func (r *Register) mask() uint64 {
+	if r.width == 64 {
+		return ^uint64(0)
+	}
+	return 1<<r.width - 1
+}
+
+func (r *Register) operandBits(v *big.Int) uint64 {
+	mod := new(big.Int).Lsh(big.NewInt(1), uint(r.width))
+	return new(big.Int).Mod(v, mod).Uint64()
+}
+
+func (r *Register) fits(v *big.Int) bool {
+	min, max := new(big.Int), new(big.Int).Lsh(big.NewInt(1), uint(r.width))
+	if r.signed {
+		max.Rsh(max, 1)
+		min.Neg(max)
+	}
+	max.Sub(max, big.NewInt(1))
+	return v.Cmp(min) >= 0 && v.Cmp(max) <= 0
+}
+
+// Kind describes the register type the way go names it, e.g. int8 or uint64
+func (r *Register) Kind() string {
+	if r.signed {
+		return fmt.Sprintf("int%d", r.width)
+	}
+	return fmt.Sprintf("uint%d", r.width)
+}
@@ -0,0 +1,225 @@
+package programmer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister_Arithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		width    int
+		signed   bool
+		overflow Overflow
+		ops      func(r *Register) error
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "int8 wraps on overflow",
+			width:    8,
+			signed:   true,
+			overflow: Wrap,
+			ops: func(r *Register) error {
+				return r.Add(big.NewInt(130))
+			},
+			want: "-126",
+		},
+		{
+			name:     "int8 fails on overflow",
+			width:    8,
+			signed:   true,
+			overflow: Error,
+			ops: func(r *Register) error {
+				return r.Add(big.NewInt(130))
+			},
+			wantErr: true,
+		},
+		{
+			name:     "uint16 wraps below zero",
+			width:    16,
+			signed:   false,
+			overflow: Wrap,
+			ops: func(r *Register) error {
+				return r.Subtract(big.NewInt(1))
+			},
+			want: "65535",
+		},
+		{
+			name:     "uint64 multiply overflow is detected",
+			width:    64,
+			signed:   false,
+			overflow: Error,
+			ops: func(r *Register) error {
+				if err := r.Add(new(big.Int).SetUint64(1 << 63)); err != nil {
+					return err
+				}
+				return r.Multiply(big.NewInt(2))
+			},
+			wantErr: true,
+		},
+		{
+			name:     "division truncates toward zero",
+			width:    32,
+			signed:   true,
+			overflow: Error,
+			ops: func(r *Register) error {
+				if err := r.Subtract(big.NewInt(7)); err != nil {
+					return err
+				}
+				return r.Divide(big.NewInt(2))
+			},
+			want: "-3",
+		},
+		{
+			name:     "division by zero",
+			width:    32,
+			signed:   true,
+			overflow: Wrap,
+			ops: func(r *Register) error {
+				return r.Divide(big.NewInt(0))
+			},
+			wantErr: true,
+		},
+		{
+			name:     "negating the minimum fails on error overflow",
+			width:    8,
+			signed:   true,
+			overflow: Error,
+			ops: func(r *Register) error {
+				if err := r.Subtract(big.NewInt(128)); err != nil {
+					return err
+				}
+				return r.Neg()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Register{width: tt.width, signed: tt.signed, overflow: tt.overflow}
+			err := tt.ops(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Register error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && r.Dec() != tt.want {
+				t.Errorf("Register.Dec() = %v, want %v", r.Dec(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRegister_Bitwise(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		signed bool
+		start  int64
+		op     func(r *Register)
+		want   string
+	}{
+		{
+			name:  "and",
+			width: 8,
+			start: 0b1100,
+			op:    func(r *Register) { r.And(big.NewInt(0b1010)) },
+			want:  "0b0000_1000",
+		},
+		{
+			name:  "or",
+			width: 8,
+			start: 0b1100,
+			op:    func(r *Register) { r.Or(big.NewInt(0b1010)) },
+			want:  "0b0000_1110",
+		},
+		{
+			name:  "xor with negative operand uses its two's complement",
+			width: 8,
+			start: 0b1100,
+			op:    func(r *Register) { r.Xor(big.NewInt(-1)) },
+			want:  "0b1111_0011",
+		},
+		{
+			name:  "not",
+			width: 16,
+			start: 0,
+			op:    func(r *Register) { r.Not() },
+			want:  "0b1111_1111_1111_1111",
+		},
+		{
+			name:  "shl drops bits",
+			width: 8,
+			start: 0b1100_0001,
+			op:    func(r *Register) { _ = r.Shl(2) },
+			want:  "0b0000_0100",
+		},
+		{
+			name:   "signed shr keeps the sign",
+			width:  8,
+			signed: true,
+			start:  -128,
+			op:     func(r *Register) { _ = r.Shr(3) },
+			want:   "0b1111_0000",
+		},
+		{
+			name:  "unsigned shr fills with zero",
+			width: 8,
+			start: 0b1000_0000,
+			op:    func(r *Register) { _ = r.Shr(3) },
+			want:  "0b0001_0000",
+		},
+		{
+			name:  "rol wraps the bits around",
+			width: 8,
+			start: 0b1000_0001,
+			op:    func(r *Register) { r.Rol(1) },
+			want:  "0b0000_0011",
+		},
+		{
+			name:  "ror wraps the bits around",
+			width: 8,
+			start: 0b1000_0001,
+			op:    func(r *Register) { r.Ror(1) },
+			want:  "0b1100_0000",
+		},
+		{
+			name:  "rol on 64 bits",
+			width: 64,
+			start: 1,
+			op:    func(r *Register) { r.Rol(65) },
+			want:  "0b0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0010",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Register{width: tt.width, signed: tt.signed, overflow: Wrap}
+			assert.NoError(t, r.Set(big.NewInt(tt.start)))
+			tt.op(r)
+			if got := r.Bin(); got != tt.want {
+				t.Errorf("Register.Bin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegister_SetWidth(t *testing.T) {
+	r := New()
+	assert.NoError(t, r.Set(big.NewInt(300)))
+	assert.NoError(t, r.SetOverflow(Error))
+	assert.Error(t, r.SetWidth(8))
+	assert.Equal(t, 64, r.Width())
+	assert.Equal(t, "300", r.Dec())
+
+	assert.NoError(t, r.SetOverflow(Wrap))
+	assert.NoError(t, r.SetWidth(8))
+	assert.Equal(t, "int8", r.Kind())
+	assert.Equal(t, "44", r.Dec())
+	assert.Error(t, r.SetWidth(12))
+
+	r.SetSigned(false)
+	assert.NoError(t, r.Set(big.NewInt(-1)))
+	assert.Equal(t, "dec 255  hex 0xFF  oct 0o377  bin 0b1111_1111", r.String())
+}
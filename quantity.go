@@ -0,0 +1,140 @@
+package main
+
+// handler of the quantities. an operand may be followed by a unit, e.g. add 5 km or multiply 2 h,
+// and current carries the unit it ends up with. adding or subtracting checks the dimensions match
+// and converts the operand to the unit of current, convert changes the unit of current.
+
+import (
+	"errors"
+	"fmt"
+
+	"gitlab.com/atthoriq/calculator-project/calculator"
+	"gitlab.com/atthoriq/calculator-project/units"
+)
+
+const (
+	convert = "convert"
+	unitOp  = "unit"
+
+	unitNone = "none"
+)
+
+// parseQuantity reads an operand optionally followed by its unit. the last argument is taken as the unit
+// only when there is something before it, so 1 1/2 is still a mixed number
+func (ch *calculatorHandler) parseQuantity(args []string) (operand, units.Unit, error) {
+	if len(args) < 2 {
+		value, err := ch.parseUncertain(args)
+		return value, units.None, err
+	}
+
+	unit, err := units.Parse(args[len(args)-1])
+	if err != nil {
+		// not a unit, the arguments are a number on their own
+		value, err := ch.parseUncertain(args)
+		return value, units.None, err
+	}
+
+	value, err := ch.parseUncertain(args[:len(args)-1])
+	if err != nil {
+		return operand{}, units.None, err
+	}
+	if value.percent {
+		return operand{}, units.None, errors.New("a percent has no unit")
+	}
+
+	return value, unit, nil
+}
+
+// inCurrentUnit converts the value v of unit u to be added to current and tells the unit it ends up in.
+// an untouched current without unit takes u as it is, any other current without unit is a plain number
+func (ch *calculatorHandler) inCurrentUnit(v float64, u units.Unit) (float64, units.Unit, error) {
+	if u.IsNone() {
+		return v, u, nil
+	}
+
+	cu := ch.calculator.GetUnit()
+	if cu.IsNone() && ch.calculator.GetResult() == 0 && len(ch.calculator.GetHistory()) == 0 {
+		return v, u, nil
+	}
+
+	// an added quantity is a difference, 5 °C added to 20 °F adds 9 °F
+	v, err := units.ConvertDifference(v, u, cu)
+	return v, cu, err
+}
+
+// convertUnit queues the conversion of current to the unit to
+func (ch *calculatorHandler) convertUnit(to units.Unit) (calculator.NewCalculator, error) {
+	from := ch.calculator.GetUnit()
+	if from.IsNone() {
+		return nil, errors.New("current has no unit, set it with unit <unit>")
+	}
+
+	a, b, err := units.Affine(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// converting is multiplying by the ratio of the units, plus the offset of temperatures
+	return ch.calculator.Convert(a, b, to), nil
+}
+
+// formatCurrent formats current followed by its currency or its unit
+func (ch *calculatorHandler) formatCurrent(v float64) string {
+	if code := ch.calculator.GetCurrency(); code != "" {
+		return ch.formatAmount(v, code)
+	}
+
+	u := ch.calculator.GetUnit()
+	if u.IsNone() {
+		return ch.formatBounded(v) + ch.approximation(v)
+	}
+
+	return ch.formatBounded(v) + " " + u.String()
+}
+
+// handleUnit handles convert and unit
+func (ch *calculatorHandler) handleUnit(op string, args []string) (string, error) {
+	switch op {
+	case convert:
+		if len(args) != 1 {
+			return "", errInvalidInput
+		}
+
+		to, err := units.Parse(args[0])
+		if err != nil {
+			return "", err
+		}
+
+		calc, err := ch.convertUnit(to)
+		if err != nil {
+			return "", err
+		}
+
+		res := calc.GetResult()
+		return ch.formatCurrent(res), nil
+	case unitOp:
+		switch {
+		case len(args) == 0:
+			u := ch.calculator.GetUnit()
+			if u.IsNone() {
+				return unitNone, nil
+			}
+			return fmt.Sprintf("%s (%s)", u, u.Dimension()), nil
+		case len(args) == 1 && args[0] == unitNone:
+			ch.calculator.SetUnit(units.None)
+		case len(args) == 1:
+			u, err := units.Parse(args[0])
+			if err != nil {
+				return "", err
+			}
+			ch.calculator.SetUnit(u)
+		default:
+			return "", errInvalidInput
+		}
+
+		res := ch.calculator.GetResult()
+		return ch.formatCurrent(res), nil
+	}
+
+	return "", errInvalidInput
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_calculatorHandler_Handle_Quantity(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "add converts to the unit of current",
+			commands: []string{"add 5 km", "add 300 m"},
+			want:     "5.30 km",
+		},
+		{
+			name:     "subtract converts to the unit of current",
+			commands: []string{"add 2 h", "subtract 30 min"},
+			want:     "1.50 h",
+		},
+		{
+			name:     "add plain number keeps the unit",
+			commands: []string{"add 5 km", "add 1"},
+			want:     "6.00 km",
+		},
+		{
+			name:     "add of another dimension",
+			commands: []string{"add 5 km", "add 3 kg"},
+			wantErr:  "kg (mass) doesn't match km (length)",
+		},
+		{
+			name:     "add a quantity to a plain number",
+			commands: []string{"add 3", "add 5 km"},
+			wantErr:  "km (length) doesn't match plain number (no dimension)",
+		},
+		{
+			name:     "add a quantity after cancel",
+			commands: []string{"add 3", "cancel", "add 5 km"},
+			want:     "5.00 km",
+		},
+		{
+			name:     "invert back to a plain number",
+			commands: []string{"add 5 km", "multiply 2", "invert"},
+			want:     "0.00",
+		},
+		{
+			name:     "multiply multiplies the units",
+			commands: []string{"add 5 km", "multiply 2 h"},
+			want:     "10.00 km·h",
+		},
+		{
+			name:     "divide divides the units",
+			commands: []string{"add 100 km", "divide 2 h"},
+			want:     "50.00 km/h",
+		},
+		{
+			name:     "convert",
+			commands: []string{"add 5 km", "convert mi"},
+			want:     "3.11 mi",
+		},
+		{
+			name:     "convert a derived unit",
+			commands: []string{"add 100 km", "divide 2 h", "convert m/s"},
+			want:     "13.89 m/s",
+		},
+		{
+			name:     "convert a temperature",
+			commands: []string{"add 20 degC", "convert degF"},
+			want:     "68.00 °F",
+		},
+		{
+			name:     "repeat skips convert",
+			commands: []string{"add 5 km", "convert mi", "repeat 1"},
+			want:     "3.11 mi",
+		},
+		{
+			name:     "repeat after convert",
+			commands: []string{"add 5 km", "convert mi", "multiply 2", "repeat 2"},
+			want:     "12.43 mi",
+		},
+		{
+			name:     "invert a temperature conversion",
+			commands: []string{"add 20 degC", "convert degF", "invert"},
+			want:     "0.00",
+		},
+		{
+			name:     "formula of a temperature conversion",
+			commands: []string{"add 20 degC", "convert degF", "formula"},
+			want:     "(x + 20) * 1.8 + 32",
+		},
+		{
+			name:     "convert to another dimension",
+			commands: []string{"add 5 km", "convert s"},
+			wantErr:  "km (length) doesn't match s (time)",
+		},
+		{
+			name:     "convert without unit",
+			commands: []string{"add 5", "convert km"},
+			wantErr:  "current has no unit, set it with unit <unit>",
+		},
+		{
+			name:     "sqrt of an area",
+			commands: []string{"add 9 m^2", "sqrt"},
+			want:     "3.00 m",
+		},
+		{
+			name:     "sqrt of a length",
+			commands: []string{"add 4 m", "sqrt"},
+			wantErr:  "root 2 of m has no unit",
+		},
+		{
+			name:     "sqr",
+			commands: []string{"add 3 m", "sqr"},
+			want:     "9.00 m²",
+		},
+		{
+			name:     "percent with a unit",
+			commands: []string{"add 5 km", "add 15% km"},
+			wantErr:  "a percent has no unit",
+		},
+		{
+			name:     "mixed number is not a unit",
+			commands: []string{"add 1 1/2"},
+			want:     "1.50",
+		},
+		{
+			name:     "show the unit",
+			commands: []string{"add 5 km", "unit"},
+			want:     "km (length)",
+		},
+		{
+			name:     "set the unit",
+			commands: []string{"add 5", "unit N"},
+			want:     "5.00 N",
+		},
+		{
+			name:     "clear the unit",
+			commands: []string{"add 5 km", "unit none", "unit"},
+			want:     "none",
+		},
+		{
+			name:     "cancel clears the unit",
+			commands: []string{"add 5 km", "cancel", "add 2"},
+			want:     "2.00",
+		},
+		{
+			name:     "repeat keeps the units",
+			commands: []string{"add 3 m", "multiply 2 m", "repeat 1"},
+			want:     "12.00 m³",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleAll(t, tt.commands)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
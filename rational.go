@@ -0,0 +1,109 @@
+package main
+
+// handler of the rational approximations. frac finds the simplest fraction close to current, like 1/3 for
+// 0.333333, cf writes its continued fraction, and frac on shows the fraction next to every result.
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gitlab.com/atthoriq/calculator-project/rational"
+)
+
+const (
+	fracOp = "frac"
+	cfOp   = "cf"
+
+	fracOn  = "on"
+	fracOff = "off"
+
+	// fracTolerance is the default distance from current to its fraction, enough for 6 typed decimals
+	fracTolerance = 1e-6
+	// fracDenominator and fracCloseness bound the fractions shown next to the results, so only the ones the result
+	// is computed from show up, like 1/3 for 1 divided by 3, and not the many fractions near any number
+	fracDenominator = 1000
+	fracCloseness   = 1e-9
+	cfTerms         = 20
+)
+
+// handleRational handles frac and cf
+func (ch *calculatorHandler) handleRational(op string, args []string) (string, error) {
+	if op == cfOp {
+		return ch.handleContinuedFraction(args)
+	}
+
+	tolerance := fracTolerance
+	switch {
+	case len(args) > 1:
+		return "", errInvalidInput
+	case len(args) == 1 && (args[0] == fracOn || args[0] == fracOff):
+		ch.fraction = args[0] == fracOn
+		return "frac " + args[0], nil
+	case len(args) == 1:
+		v, err := ch.parseOperand(args)
+		if err != nil {
+			return "", err
+		}
+		if v.percent {
+			return "", errInvalidInput
+		}
+		tolerance = v.value
+	}
+
+	current := ch.calculator.GetResult()
+	f, err := rational.Best(current, tolerance)
+	if err != nil {
+		return "", err
+	}
+
+	if f.Float64() == current {
+		return f.String(), nil
+	}
+	return fmt.Sprintf("≈ %s (off by %s)", f, ch.formatError(current-f.Float64())), nil
+}
+
+// handleContinuedFraction writes the continued fraction of current as [a0; a1, a2, ...], up to the number of terms of the argument
+func (ch *calculatorHandler) handleContinuedFraction(args []string) (string, error) {
+	terms := cfTerms
+	switch len(args) {
+	case 0:
+	case 1:
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return "", fmt.Errorf("invalid number of terms %q", args[0])
+		}
+		terms = n
+	default:
+		return "", errInvalidInput
+	}
+
+	cf, err := rational.ContinuedFraction(ch.calculator.GetResult(), terms)
+	if err != nil {
+		return "", err
+	}
+
+	rest := make([]string, len(cf)-1)
+	for i, a := range cf[1:] {
+		rest[i] = strconv.FormatInt(a, 10)
+	}
+	if len(rest) == 0 {
+		return fmt.Sprintf("[%d]", cf[0]), nil
+	}
+	return fmt.Sprintf("[%d; %s]", cf[0], strings.Join(rest, ", ")), nil
+}
+
+// approximation is the fraction shown next to a result with frac on, empty when v is an integer
+// or when no simple fraction is close enough
+func (ch *calculatorHandler) approximation(v float64) string {
+	if !ch.fraction || v == math.Trunc(v) {
+		return ""
+	}
+
+	f, err := rational.Best(v, fracCloseness*math.Max(1, math.Abs(v)))
+	if err != nil || f.Den > fracDenominator {
+		return ""
+	}
+	return " ≈ " + f.String()
+}
@@ -0,0 +1,138 @@
+package rational
+
+// rational approximations through continued fractions. a float is a fraction with a power of 2 as
+// denominator, its continued fraction finds the simple fractions close to it, like 1/3 for 0.333333.
+// numerators and denominators stay within 2^53, so the fractions are exact as float64 as well.
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// MaxExact bounds the numerators and the denominators, every integer up to it is exact as float64
+const MaxExact = 1 << 53
+
+// Fraction is Num/Den, Den being positive
+type Fraction struct {
+	Num, Den int64
+}
+
+func (f Fraction) Float64() float64 {
+	return float64(f.Num) / float64(f.Den)
+}
+
+// String writes the fraction as 1/3, or as 3 when it is an integer
+func (f Fraction) String() string {
+	if f.Den == 1 {
+		return strconv.FormatInt(f.Num, 10)
+	}
+	return fmt.Sprintf("%d/%d", f.Num, f.Den)
+}
+
+// ContinuedFraction returns up to max terms [a0; a1, a2, ...] of the continued fraction of x. it stops
+// once the fraction of the terms is x, or when the next one would go beyond MaxExact
+func ContinuedFraction(x float64, max int) ([]int64, error) {
+	if err := check(x); err != nil {
+		return nil, err
+	}
+
+	terms := []int64{}
+	c := newConvergents()
+	r := x
+	for len(terms) < max {
+		a := math.Floor(r)
+		if !c.fits(a) {
+			break
+		}
+		c.next(a)
+		terms = append(terms, int64(a))
+
+		if r == a || c.fraction().Float64() == x {
+			// a last term of 1 is the same fraction as one more on the term before, 1/3 is [0; 3] rather than [0; 2, 1]
+			if n := len(terms); n > 1 && terms[n-1] == 1 {
+				terms = append(terms[:n-2], terms[n-2]+1)
+			}
+			break
+		}
+		r = 1 / (r - a)
+	}
+
+	return terms, nil
+}
+
+// Best returns the fraction with the smallest denominator within tolerance of x, e.g. 1/3 for 0.333333 within 1e-6.
+// it is a convergent of the continued fraction of x, or a fraction between two of them
+func Best(x, tolerance float64) (Fraction, error) {
+	if err := check(x); err != nil {
+		return Fraction{}, err
+	}
+	if !(tolerance >= 0) {
+		return Fraction{}, fmt.Errorf("tolerance %v is not positive", tolerance)
+	}
+
+	// the fractions of a negative x are the opposite of the ones of -x
+	sign, v := int64(1), x
+	if v < 0 {
+		sign, v = -1, -v
+	}
+	if v <= tolerance {
+		return Fraction{0, 1}, nil
+	}
+
+	c := newConvergents()
+	r := v
+	for {
+		a := math.Floor(r)
+		if !c.fits(a) {
+			return Fraction{}, fmt.Errorf("no fraction within %v of %v up to 2^53", tolerance, x)
+		}
+		within := func(m float64) bool {
+			return math.Abs(v-(c.h1+m*c.h)/(c.k1+m*c.k)) <= tolerance
+		}
+
+		// from a convergent to the next, the fractions (h1 + m·h)/(k1 + m·k) get closer to x as m goes up to a
+		if within(a) {
+			m := 1 + float64(sort.Search(int(a)-1, func(i int) bool { return within(float64(i + 1)) }))
+			return Fraction{sign * int64(c.h1+m*c.h), int64(c.k1 + m*c.k)}, nil
+		}
+
+		c.next(a)
+		r = 1 / (r - a)
+	}
+}
+
+// convergents are the last two fractions h/k and h1/k1 of a continued fraction, kept as floats holding exact integers
+type convergents struct {
+	h, k, h1, k1 float64
+}
+
+// newConvergents starts with the fractions 1/0 and 0/1 preceding the first term
+func newConvergents() *convergents {
+	return &convergents{h: 1, k: 0, h1: 0, k1: 1}
+}
+
+// fits tells whether the fraction after the term a stays within MaxExact
+func (c *convergents) fits(a float64) bool {
+	return math.Abs(a) <= MaxExact && math.Abs(a*c.h+c.h1) <= MaxExact && a*c.k+c.k1 <= MaxExact
+}
+
+func (c *convergents) next(a float64) {
+	c.h, c.h1 = a*c.h+c.h1, c.h
+	c.k, c.k1 = a*c.k+c.k1, c.k
+}
+
+func (c *convergents) fraction() Fraction {
+	return Fraction{int64(c.h), int64(c.k)}
+}
+
+func check(x float64) error {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return fmt.Errorf("%v is not a fraction", x)
+	}
+	if math.Abs(x) > MaxExact {
+		return fmt.Errorf("%v is too large for an exact fraction, the limit is 2^53", x)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package rational
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContinuedFraction(t *testing.T) {
+	tests := []struct {
+		name string
+		x    float64
+		max  int
+		want []int64
+	}{
+		{name: "integer", x: 3, max: 10, want: []int64{3}},
+		{name: "third", x: 1.0 / 3, max: 10, want: []int64{0, 3}},
+		{name: "rounded third", x: 0.333333, max: 10, want: []int64{0, 3, 333333}},
+		{name: "negative", x: -1.5, max: 10, want: []int64{-2, 2}},
+		{name: "pi", x: math.Pi, max: 5, want: []int64{3, 7, 15, 1, 292}},
+		{name: "golden ratio", x: math.Phi, max: 6, want: []int64{1, 1, 1, 1, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ContinuedFraction(tt.x, tt.max)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := ContinuedFraction(math.NaN(), 10)
+	assert.EqualError(t, err, "NaN is not a fraction")
+}
+
+func TestBest(t *testing.T) {
+	tests := []struct {
+		name      string
+		x         float64
+		tolerance float64
+		want      Fraction
+	}{
+		{name: "third", x: 0.333333, tolerance: 1e-6, want: Fraction{1, 3}},
+		{name: "no tolerance", x: 0.333333, tolerance: 0, want: Fraction{333333, 1000000}},
+		{name: "tight tolerance", x: 0.333333, tolerance: 1e-9, want: Fraction{332336, 997009}},
+		{name: "pi", x: math.Pi, tolerance: 1e-6, want: Fraction{355, 113}},
+		{name: "pi loosely", x: math.Pi, tolerance: 0.01, want: Fraction{22, 7}},
+		{name: "between convergents", x: 0.3, tolerance: 0.02, want: Fraction{2, 7}},
+		{name: "negative", x: -0.75, tolerance: 0, want: Fraction{-3, 4}},
+		{name: "integer", x: 2.0000001, tolerance: 1e-6, want: Fraction{2, 1}},
+		{name: "close to zero", x: 1e-9, tolerance: 1e-6, want: Fraction{0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Best(tt.x, tt.tolerance)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := Best(1, -1)
+	assert.EqualError(t, err, "tolerance -1 is not positive")
+	_, err = Best(1e300, 1)
+	assert.EqualError(t, err, "1e+300 is too large for an exact fraction, the limit is 2^53")
+}
+
+func TestFraction_String(t *testing.T) {
+	assert.Equal(t, "-2/3", Fraction{-2, 3}.String())
+	assert.Equal(t, "5", Fraction{5, 1}.String())
+}
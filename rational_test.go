@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_calculatorHandler_Handle_Rational(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "frac",
+			commands: []string{"add 0.333333", "frac"},
+			want:     "≈ 1/3 (off by 3.3e-07)",
+		},
+		{
+			name:     "exact fraction",
+			commands: []string{"add 0.75", "frac"},
+			want:     "3/4",
+		},
+		{
+			name:     "tolerance",
+			commands: []string{"add 3.14159265", "frac 0.01"},
+			want:     "≈ 22/7 (off by 0.0013)",
+		},
+		{
+			name:     "negative",
+			commands: []string{"subtract 1", "divide 3", "frac"},
+			want:     "-1/3",
+		},
+		{
+			name:     "continued fraction",
+			commands: []string{"add 3.14159265358979", "cf 5"},
+			want:     "[3; 7, 15, 1, 292]",
+		},
+		{
+			name:     "continued fraction of a ratio",
+			commands: []string{"add 43", "divide 19", "cf"},
+			want:     "[2; 3, 1, 4]",
+		},
+		{
+			name:     "continued fraction of an integer",
+			commands: []string{"add 5", "cf"},
+			want:     "[5]",
+		},
+		{
+			name:     "shown next to the results",
+			commands: []string{"frac on", "add 1", "divide 3"},
+			want:     "0.33 ≈ 1/3",
+		},
+		{
+			name:     "not shown for integers",
+			commands: []string{"frac on", "add 2"},
+			want:     "2.00",
+		},
+		{
+			name:     "not shown when it's only close",
+			commands: []string{"frac on", "add 0.333333"},
+			want:     "0.33",
+		},
+		{
+			name:     "switched off",
+			commands: []string{"frac on", "frac off", "add 0.5"},
+			want:     "0.50",
+		},
+		{
+			name:     "not a fraction",
+			commands: []string{"add 1", "divide 0", "frac"},
+			wantErr:  "NaN is not a fraction",
+		},
+		{
+			name:     "invalid number of terms",
+			commands: []string{"cf 0"},
+			wantErr:  `invalid number of terms "0"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleAll(t, tt.commands)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
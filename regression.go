@@ -0,0 +1,119 @@
+package main
+
+// regression commands of the stats mode on the x,y points of the dataset.
+// predict uses the last fit asked for, linreg by default.
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/atthoriq/calculator-project/stats"
+)
+
+const (
+	pointOp   = "point"
+	linregOp  = "linreg"
+	polyfitOp = "polyfit"
+	expfitOp  = "expfit"
+	predictOp = "predict"
+)
+
+// fitter fits a curve to the points
+type fitter func(xs, ys []float64) (stats.Fit, error)
+
+func linearFitter(xs, ys []float64) (stats.Fit, error) {
+	return stats.LinearFit(xs, ys)
+}
+
+func exponentialFitter(xs, ys []float64) (stats.Fit, error) {
+	return stats.ExponentialFit(xs, ys)
+}
+
+func polynomialFitter(degree int) fitter {
+	return func(xs, ys []float64) (stats.Fit, error) {
+		return stats.PolynomialFit(xs, ys, degree)
+	}
+}
+
+// handleRegression handles the commands on the points of the dataset
+func (ch *calculatorHandler) handleRegression(op string, args []string) (string, error) {
+	switch op {
+	case pointOp:
+		v, err := ch.parseValues(args, 2, 2)
+		if err != nil {
+			return "", err
+		}
+
+		ch.calculator.PushPoint(v[0], v[1])
+		return fmt.Sprintf("%d: (%s, %s)", len(ch.calculator.GetPoints()), ch.format(v[0]), ch.format(v[1])), nil
+	case linregOp, expfitOp:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
+		fit := fitter(linearFitter)
+		if op == expfitOp {
+			fit = exponentialFitter
+		}
+		return ch.describeFit(fit)
+	case polyfitOp:
+		v, err := ch.parseValues(args, 1, 1)
+		if err != nil {
+			return "", err
+		}
+		if v[0] != float64(int(v[0])) {
+			return "", fmt.Errorf("invalid degree %s: it has to be an integer", args[0])
+		}
+
+		return ch.describeFit(polynomialFitter(int(v[0])))
+	case predictOp:
+		v, err := ch.parseValues(args, 1, 1)
+		if err != nil {
+			return "", err
+		}
+
+		fit := ch.fit
+		if fit == nil {
+			fit = linearFitter
+		}
+		f, err := fit(ch.points())
+		if err != nil {
+			return "", err
+		}
+		return ch.format(f.Predict(v[0])), nil
+	default:
+		return "", errInvalidInput
+	}
+}
+
+// describeFit fits the points, keeps the fitter for predict and prints the parameters of the curve
+func (ch *calculatorHandler) describeFit(fit fitter) (string, error) {
+	f, err := fit(ch.points())
+	if err != nil {
+		return "", err
+	}
+	ch.fit = fit
+
+	var parts []string
+	switch f := f.(type) {
+	case stats.Linear:
+		parts = []string{"slope " + ch.format(f.Slope), "intercept " + ch.format(f.Intercept)}
+	case stats.Polynomial:
+		for i, c := range f.Coefficients {
+			parts = append(parts, fmt.Sprintf("c%d %s", i, ch.format(c)))
+		}
+	case stats.Exponential:
+		parts = []string{"a " + ch.format(f.A), "b " + ch.format(f.B)}
+	}
+	parts = append(parts, "r² "+ch.format(f.RSquared()))
+	return strings.Join(parts, "  "), nil
+}
+
+// points returns the x and y of the points of the dataset
+func (ch *calculatorHandler) points() (xs, ys []float64) {
+	for _, p := range ch.calculator.GetPoints() {
+		xs = append(xs, p.X)
+		ys = append(ys, p.Y)
+	}
+	return xs, ys
+}
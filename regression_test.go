@@ -0,0 +1,85 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_calculatorHandler_Handle_Regression(t *testing.T) {
+	points := []string{"mode stats", "point 1 2", "point 2 4", "point 3 5", "point 4 4", "point 5 5"}
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "point",
+			commands: []string{"mode stats", "point 1 2.3"},
+			want:     "1: (1.00, 2.30)",
+		},
+		{
+			name:     "linreg",
+			commands: append(points, "linreg"),
+			want:     "slope 0.60  intercept 2.20  r² 0.60",
+		},
+		{
+			name:     "predict by linreg by default",
+			commands: append(points, "predict 6"),
+			want:     "5.80",
+		},
+		{
+			name:     "polyfit",
+			commands: []string{"mode stats", "point -1 6", "point 0 1", "point 1 2", "point 2 9", "polyfit 2"},
+			want:     "c0 1.00  c1 -2.00  c2 3.00  r² 1.00",
+		},
+		{
+			name:     "predict by the last fit",
+			commands: []string{"mode stats", "point -1 6", "point 0 1", "point 1 2", "point 2 9", "polyfit 2", "predict 3"},
+			want:     "22.00",
+		},
+		{
+			name:     "expfit",
+			commands: []string{"mode stats", "point 0 2", "point 1 4", "point 2 8", "expfit"},
+			want:     "a 2.00  b 0.69  r² 1.00",
+		},
+		{
+			name:     "expfit with negative y",
+			commands: []string{"mode stats", "point 0 2", "point 1 -4", "expfit"},
+			wantErr:  true,
+		},
+		{
+			name:     "linreg without points",
+			commands: []string{"mode stats", "linreg"},
+			wantErr:  true,
+		},
+		{
+			name:     "point with a single value",
+			commands: []string{"mode stats", "point 1"},
+			wantErr:  true,
+		},
+		{
+			name:     "polyfit with fractional degree",
+			commands: append(points, "polyfit 1.5"),
+			wantErr:  true,
+		},
+		{
+			name:     "clear-data removes the points",
+			commands: append(points, "clear-data", "predict 1"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleAll(t, tt.commands)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("calculatorHandler.Handle() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+// sexagesimal numbers: angles in degrees, minutes and seconds like 12°30'15" and times in
+// hours, minutes and seconds like 1:30:15. both are read as operand and can be the display of the results.
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	displayOp = "display"
+	dms       = "dms"
+	hms       = "hms"
+	dec       = "dec"
+)
+
+// sexagesimalMarks are the marks of degrees, minutes and seconds with their look-alikes. two apostrophes mark seconds as well
+var sexagesimalMarks = map[rune]int{'°': 0, '\'': 1, '′': 1, '’': 1, '"': 2, '″': 2, '”': 2}
+
+// isSexagesimal tells whether the literal is written in degrees, minutes and seconds or as h:m:s.
+// a lone apostrophe is a group separator in some locales, it marks minutes only at the end of the literal
+func isSexagesimal(s string) bool {
+	return strings.ContainsAny(s, "°:\"′″”") || strings.HasSuffix(s, "'") || strings.HasSuffix(s, "’")
+}
+
+// parseSexagesimal reads degrees, minutes and seconds like 12°30'15", 12°30.5' or 45°S, or hours like 1:30 and 1:30:15.5
+func (l locale) parseSexagesimal(s string) (float64, error) {
+	body := strings.TrimLeft(s, "+-")
+	if len(s)-len(body) > 1 {
+		return 0, &literalError{s, 2, "unexpected sign"}
+	}
+	sign := 1.0
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+	}
+	offset := len(s) - len(body)
+
+	if strings.Contains(body, ":") {
+		v, err := l.parseColons(s, body, offset)
+		return sign * v, err
+	}
+
+	// a hemisphere turns south and west negative
+	runes := []rune(body)
+	if len(runes) > 0 {
+		switch runes[len(runes)-1] {
+		case 'S', 'W':
+			sign = -sign
+			fallthrough
+		case 'N', 'E':
+			if offset > 0 {
+				return 0, &literalError{s, 1, "sign and hemisphere both given"}
+			}
+			runes = runes[:len(runes)-1]
+		}
+	}
+
+	value, next, start := 0.0, 0, 0
+	for i := 0; i < len(runes); i++ {
+		mark, ok := sexagesimalMarks[runes[i]]
+		if !ok {
+			continue
+		}
+		// two apostrophes are seconds
+		if mark == 1 && i+1 < len(runes) && runes[i+1] == '\'' {
+			mark = 2
+		}
+		if mark < next {
+			return 0, &literalError{s, offset + i + 1, fmt.Sprintf("unexpected %q", runes[i])}
+		}
+
+		v, err := l.parseSexagesimalPart(s, string(runes[start:i]), offset+start, next > 0)
+		if err != nil {
+			return 0, err
+		}
+		value += v / math.Pow(60, float64(mark))
+
+		if mark == 2 && runes[i] == '\'' {
+			i++
+		}
+		next, start = mark+1, i+1
+	}
+	if start < len(runes) {
+		return 0, &literalError{s, offset + start + 1, "missing °, ' or \" mark"}
+	}
+	if next == 0 {
+		return 0, &literalError{s, offset + 1, "missing digits"}
+	}
+
+	return sign * value, nil
+}
+
+// parseColons reads h:m or h:m:s, only the last part may have decimals
+func (l locale) parseColons(input, s string, offset int) (float64, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, &literalError{input, offset + len([]rune(strings.Join(parts[:3], ":"))) + 1, "too many colons"}
+	}
+
+	value := 0.0
+	for i, part := range parts {
+		if i < len(parts)-1 && strings.ContainsRune(part, l.decimalRune()) {
+			return 0, &literalError{input, offset + 1, "only the last part may have decimals"}
+		}
+
+		v, err := l.parseSexagesimalPart(input, part, offset, i > 0)
+		if err != nil {
+			return 0, err
+		}
+		value += v / math.Pow(60, float64(i))
+		offset += len([]rune(part)) + 1
+	}
+
+	return value, nil
+}
+
+// parseSexagesimalPart reads an unsigned part, minutes and seconds are below 60
+func (l locale) parseSexagesimalPart(input, part string, offset int, belowSixty bool) (float64, error) {
+	if part == "" {
+		return 0, &literalError{input, offset + 1, "missing digits"}
+	}
+	if strings.ContainsAny(part, "+-") {
+		return 0, &literalError{input, offset + strings.IndexAny(part, "+-") + 1, "unexpected sign"}
+	}
+
+	v, err := l.parseDecimal(input, part, offset)
+	if err != nil {
+		return 0, err
+	}
+	if belowSixty && v >= 60 {
+		return 0, &literalError{input, offset + 1, fmt.Sprintf("%s is not below 60", part)}
+	}
+	return v, nil
+}
+
+// decimalRune is the decimal separator of the locale
+func (l locale) decimalRune() rune {
+	if l.tag == "" {
+		return '.'
+	}
+	return l.decimal
+}
+
+// formatSexagesimal writes v as 12°30'15.00" in dms, or as 1:30:15.00 in hms.
+// the seconds have the decimals of a fixed format, 2 otherwise
+func (ch *calculatorHandler) formatSexagesimal(v float64, notation string) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return ch.locale.localize(ch.numberFormat.format(v))
+	}
+
+	decimals := defaultDecimals
+	if ch.numberFormat.style == formatFixed {
+		decimals = ch.numberFormat.digits
+	}
+
+	// count in units of the last decimal of the seconds so the rounding carries to minutes and degrees
+	scale := math.Pow(10, float64(decimals))
+	units := math.Round(math.Abs(v) * 3600 * scale)
+	if units > 1<<53 {
+		return ch.locale.localize(ch.numberFormat.format(v))
+	}
+
+	whole := math.Floor(units / (3600 * scale))
+	units -= whole * 3600 * scale
+	minutes := math.Floor(units / (60 * scale))
+	seconds := (units - minutes*60*scale) / scale
+
+	sign := ""
+	if v < 0 && (whole > 0 || minutes > 0 || seconds > 0) {
+		sign = "-"
+	}
+	width := 2
+	if decimals > 0 {
+		width += decimals + 1
+	}
+	sec := ch.locale.localize(fmt.Sprintf("%0*.*f", width, decimals, seconds))
+
+	if notation == hms {
+		return fmt.Sprintf("%s%.0f:%02.0f:%s", sign, whole, minutes, sec)
+	}
+	return fmt.Sprintf("%s%.0f°%02.0f'%s\"", sign, whole, minutes, sec)
+}
+
+// handleSexagesimal shows current in a notation, or sets the notation of every result with display
+func (ch *calculatorHandler) handleSexagesimal(op string, args []string) (string, error) {
+	if op != displayOp {
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
+		res := ch.calculator.GetResult()
+		if op == dec {
+			return ch.locale.localize(ch.numberFormat.format(res)), nil
+		}
+		return ch.formatSexagesimal(res, op), nil
+	}
+
+	switch {
+	case len(args) == 0:
+		if ch.display == "" {
+			return dec, nil
+		}
+		return ch.display, nil
+	case len(args) > 1:
+		return "", errInvalidInput
+	}
+
+	switch args[0] {
+	case dec:
+		ch.display = ""
+	case dms, hms:
+		ch.display = args[0]
+	default:
+		return "", fmt.Errorf("unknown display %q: use dec, dms or hms", args[0])
+	}
+
+	return "display " + args[0], nil
+}
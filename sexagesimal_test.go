@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_calculatorHandler_Handle_Sexagesimal(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "dms input",
+			commands: []string{"add 12°30'15\""},
+			want:     "12.50",
+		},
+		{
+			name:     "show current in dms",
+			commands: []string{"add 12.504167", "dms"},
+			want:     "12°30'15.00\"",
+		},
+		{
+			name:     "show current in hms",
+			commands: []string{"add 1.5", "hms"},
+			want:     "1:30:00.00",
+		},
+		{
+			name:     "show current in decimal while displaying dms",
+			commands: []string{"display dms", "add 12°30'", "dec"},
+			want:     "12.50",
+		},
+		{
+			name:     "display dms",
+			commands: []string{"display dms", "add 12°30'15\"", "add 0°29'45\""},
+			want:     "13°00'00.00\"",
+		},
+		{
+			name:     "display hms",
+			commands: []string{"display hms", "add 1:45", "add 0:20:30"},
+			want:     "2:05:30.00",
+		},
+		{
+			name:     "negative angle",
+			commands: []string{"display dms", "subtract 0°0'1.5\""},
+			want:     "-0°00'01.50\"",
+		},
+		{
+			name:     "rounding carries to the minutes",
+			commands: []string{"format fixed 0", "add 10.9999999", "dms"},
+			want:     "11°00'00\"",
+		},
+		{
+			name:     "seconds decimals follow the fixed format",
+			commands: []string{"format fixed 3", "add 0.0001", "dms"},
+			want:     "0°00'00.360\"",
+		},
+		{
+			name:     "seconds in the decimal separator of the locale",
+			commands: []string{"locale de-DE", "add 0°0'1,25\"", "dms"},
+			want:     "0°00'01,25\"",
+		},
+		{
+			name:     "show the display",
+			commands: []string{"display hms", "display"},
+			want:     "hms",
+		},
+		{
+			name:     "back to decimal",
+			commands: []string{"display dms", "display dec", "add 1:30"},
+			want:     "1.50",
+		},
+		{
+			name:     "unknown display",
+			commands: []string{"display grad"},
+			wantErr:  `unknown display "grad": use dec, dms or hms`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleAll(t, tt.commands)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
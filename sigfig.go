@@ -0,0 +1,85 @@
+package main
+
+// significant figures of lab calculations. in sigfig mode the operands are measured with the precision
+// they are typed with, 2.50 is known to the hundredth and 1200 to the hundred, and results are shown
+// rounded to the significant figures the calculator tracks. fractions, hex and sexagesimal numbers are exact.
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"gitlab.com/atthoriq/calculator-project/calculator"
+	"gitlab.com/atthoriq/calculator-project/sigfig"
+)
+
+const figuresOp = "figures"
+
+// figures infers the precision of a decimal literal of the locale, SI suffix included. other literals are exact
+func (l locale) figures(s string) sigfig.Figures {
+	body := strings.TrimLeft(s, "+-")
+	shift := 0
+	runes := []rune(body)
+	if factor, ok := siSuffixes[runes[len(runes)-1]]; ok && len(runes) > 1 {
+		shift = int(math.Round(math.Log10(factor)))
+		runes = runes[:len(runes)-1]
+	}
+
+	var sb strings.Builder
+	for _, r := range runes {
+		switch {
+		case l.tag != "" && r == l.decimal:
+			sb.WriteRune('.')
+		case r == '_' || l.isGroup(r):
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	f, err := sigfig.Parse(sb.String())
+	if err != nil {
+		return sigfig.Exact()
+	}
+	return f.Shift(shift)
+}
+
+// significant tells the calculator the operand just queued is measured, in sigfig mode only.
+// v is the value queued, which differs from the typed one when it is converted to the unit of current
+func (ch *calculatorHandler) significant(calc calculator.NewCalculator, typed operand, v float64) calculator.NewCalculator {
+	if ch.mode != modeSigfig || typed.figures.IsExact() {
+		return calc
+	}
+
+	f := typed.figures
+	if v != typed.value {
+		f = sigfig.WithDigits(v, f.Digits(typed.value))
+	}
+	return calc.Significant(f)
+}
+
+// formatFigures formats current rounded to its significant figures in sigfig mode, in the result format otherwise
+func (ch *calculatorHandler) formatFigures(v float64) string {
+	if ch.mode != modeSigfig {
+		return ch.format(v)
+	}
+
+	f := ch.calculator.GetFigures()
+	if f.IsExact() {
+		return ch.format(v)
+	}
+	return ch.locale.localize(f.Format(v))
+}
+
+// handleFigures tells the significant figures of current
+func (ch *calculatorHandler) handleFigures(args []string) (string, error) {
+	if len(args) > 0 {
+		return "", errInvalidInput
+	}
+
+	res := ch.calculator.GetResult()
+	f := ch.calculator.GetFigures()
+	if f.IsExact() {
+		return fmt.Sprintf("%s is exact", ch.format(res)), nil
+	}
+	return fmt.Sprintf("%s has %d significant figures", ch.locale.localize(f.Format(res)), f.Digits(res)), nil
+}
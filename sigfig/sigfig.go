@@ -0,0 +1,156 @@
+package sigfig
+
+// significant figures of measured values. a value typed as 2.50 is known to the hundredth, so a sum
+// is known to the place of its least precise term and a product to the significant figures of its
+// least precise factor. the figures are only tracked, values are rounded when they are shown.
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Figures is the precision of a value: the decimal place of its last significant digit, e.g. -2 for 2.50
+// and 2 for 1200. the zero value is exact, like a count or a defined constant, which never limits a result
+type Figures struct {
+	Measured bool
+	Place    int
+}
+
+// Exact is the precision of counts and defined constants
+func Exact() Figures {
+	return Figures{}
+}
+
+// At is the precision of a value known to the place 10^place
+func At(place int) Figures {
+	return Figures{Measured: true, Place: place}
+}
+
+// WithDigits is the precision of v known to n significant digits
+func WithDigits(v float64, n int) Figures {
+	return At(magnitude(v) - n + 1)
+}
+
+// Parse infers the precision of a decimal literal written with a '.' separator, e.g. 2.50, 1200, 1.20e3 or 0.0045.
+// leading zeros aren't significant, and trailing zeros of an integer aren't either unless it ends with a '.', as 1200.
+func Parse(s string) (Figures, error) {
+	body := strings.TrimLeft(s, "+-")
+	if len(s)-len(body) > 1 {
+		return Figures{}, fmt.Errorf("invalid number %q", s)
+	}
+
+	mantissa, exponent := body, 0
+	if i := strings.IndexAny(body, "eE"); i >= 0 {
+		e, err := strconv.Atoi(body[i+1:])
+		if err != nil {
+			return Figures{}, fmt.Errorf("invalid number %q", s)
+		}
+		mantissa, exponent = body[:i], e
+	}
+
+	integer, fraction, _ := strings.Cut(mantissa, ".")
+	if integer+fraction == "" || strings.Trim(integer+fraction, "0123456789") != "" {
+		return Figures{}, fmt.Errorf("invalid number %q", s)
+	}
+
+	place := -len(fraction)
+	if fraction == "" && !strings.HasSuffix(mantissa, ".") {
+		// 1200 is known to the hundred, its zeros may only be placeholders
+		trimmed := strings.TrimRight(integer, "0")
+		if trimmed != "" {
+			place = len(integer) - len(trimmed)
+		}
+	}
+
+	return At(place + exponent), nil
+}
+
+// IsExact tells whether the value isn't measured
+func (f Figures) IsExact() bool {
+	return !f.Measured
+}
+
+// Digits counts the significant digits of v, at least 1
+func (f Figures) Digits(v float64) int {
+	if f.IsExact() {
+		return math.MaxInt32
+	}
+	return max(magnitude(v)-f.Place+1, 1)
+}
+
+// Shift is the precision of a value multiplied by 10^n, e.g. for the SI suffix of 2.5k
+func (f Figures) Shift(n int) Figures {
+	if f.IsExact() {
+		return f
+	}
+	return At(f.Place + n)
+}
+
+// Sum is the precision of a sum or a difference, the place of its least precise term
+func Sum(a, b Figures) Figures {
+	switch {
+	case a.IsExact():
+		return b
+	case b.IsExact():
+		return a
+	default:
+		return At(max(a.Place, b.Place))
+	}
+}
+
+// Product is the precision of the product or the quotient result of x known to a and y known to b,
+// the significant digits of its least precise factor
+func Product(x float64, a Figures, y float64, b Figures, result float64) Figures {
+	switch {
+	case a.IsExact() && b.IsExact():
+		return Exact()
+	default:
+		return WithDigits(result, min(a.Digits(x), b.Digits(y)))
+	}
+}
+
+// Keep is the precision of result computed from x known to f alone, e.g. a root or a power, which keeps the significant digits of x
+func Keep(x float64, f Figures, result float64) Figures {
+	if f.IsExact() {
+		return f
+	}
+	return WithDigits(result, f.Digits(x))
+}
+
+// Format writes v rounded to its last significant digit, e.g. 5.0 or 0.0031. a value known to the tens or above
+// is written in scientific notation, so its trailing zeros aren't mistaken for significant ones
+func (f Figures) Format(v float64) string {
+	if f.IsExact() || math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	if f.Place <= 0 {
+		return strconv.FormatFloat(v, 'f', -f.Place, 64)
+	}
+
+	scale := math.Pow(10, float64(f.Place))
+	rounded := math.Round(v/scale) * scale
+	if rounded == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(rounded, 'e', f.Digits(rounded)-1, 64)
+}
+
+func (f Figures) String() string {
+	if f.IsExact() {
+		return "exact"
+	}
+	return fmt.Sprintf("1e%d", f.Place)
+}
+
+// magnitude is the place of the leading digit of v, 0 for 0
+func magnitude(v float64) int {
+	if v == 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	// the exponent of the shortest scientific notation, log10 is off by one around some powers of 10
+	text := strconv.FormatFloat(v, 'e', -1, 64)
+	exp, _ := strconv.Atoi(text[strings.IndexByte(text, 'e')+1:])
+	return exp
+}
@@ -0,0 +1,79 @@
+package sigfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		literal string
+		place   int
+		digits  int
+		value   float64
+	}{
+		{literal: "2.50", place: -2, digits: 3, value: 2.5},
+		{literal: "1200", place: 2, digits: 2, value: 1200},
+		{literal: "1200.", place: 0, digits: 4, value: 1200},
+		{literal: "0.0045", place: -4, digits: 2, value: 0.0045},
+		{literal: "1.20e3", place: 1, digits: 3, value: 1200},
+		{literal: "-4.0E-2", place: -3, digits: 2, value: -0.04},
+		{literal: "7", place: 0, digits: 1, value: 7},
+		{literal: ".5", place: -1, digits: 1, value: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.literal, func(t *testing.T) {
+			f, err := Parse(tt.literal)
+			assert.NoError(t, err)
+			assert.Equal(t, At(tt.place), f)
+			assert.Equal(t, tt.digits, f.Digits(tt.value))
+		})
+	}
+
+	for _, literal := range []string{"", "1/3", "0x1F", "1.2.3", "1e", "--1"} {
+		_, err := Parse(literal)
+		assert.Error(t, err, literal)
+	}
+}
+
+func TestRules(t *testing.T) {
+	// 12.11 + 0.3 is known to the tenth
+	assert.Equal(t, At(-1), Sum(At(-2), At(-1)))
+	assert.Equal(t, At(-2), Sum(At(-2), Exact()))
+	assert.True(t, Sum(Exact(), Exact()).IsExact())
+
+	// 2.50 × 1.2 = 3.0, two significant figures
+	p := Product(2.5, At(-2), 1.2, At(-1), 3)
+	assert.Equal(t, At(-1), p)
+	assert.Equal(t, 2, p.Digits(3))
+
+	// an exact factor doesn't limit the product
+	assert.Equal(t, 3, Product(2.5, At(-2), 4, Exact(), 10).Digits(10))
+	assert.True(t, Product(2, Exact(), 3, Exact(), 6).IsExact())
+
+	// the square root of 16.0 is 4.00
+	assert.Equal(t, At(-2), Keep(16, At(-1), 4))
+}
+
+func TestFigures_Format(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Figures
+		v    float64
+		want string
+	}{
+		{name: "decimals", f: At(-2), v: 3.14159, want: "3.14"},
+		{name: "trailing zero", f: At(-1), v: 3, want: "3.0"},
+		{name: "units", f: At(0), v: 1234.6, want: "1235"},
+		{name: "hundreds", f: At(2), v: 3640, want: "3.6e+03"},
+		{name: "rounding carries", f: At(2), v: 9960, want: "1.00e+04"},
+		{name: "below its place", f: At(2), v: 20, want: "0"},
+		{name: "exact", f: Exact(), v: 0.1, want: "0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.f.Format(tt.v))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_calculatorHandler_Handle_Sigfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "switch to sigfig",
+			commands: []string{"mode sigfig"},
+			want:     "mode sigfig",
+		},
+		{
+			name:     "typed decimals",
+			commands: []string{"mode sigfig", "add 2.50"},
+			want:     "2.50",
+		},
+		{
+			name:     "sum to the least precise place",
+			commands: []string{"mode sigfig", "add 12.11", "add 0.3"},
+			want:     "12.4",
+		},
+		{
+			name:     "product to the least significant figures",
+			commands: []string{"mode sigfig", "add 2.50", "multiply 1.2"},
+			want:     "3.0",
+		},
+		{
+			name:     "trailing zeros of an integer",
+			commands: []string{"mode sigfig", "add 1200", "multiply 3.00"},
+			want:     "3.6e+03",
+		},
+		{
+			name:     "fractions are exact",
+			commands: []string{"mode sigfig", "add 2.50", "multiply 1/3"},
+			want:     "0.833",
+		},
+		{
+			name:     "sqrt keeps the figures",
+			commands: []string{"mode sigfig", "add 2.0", "sqrt"},
+			want:     "1.4",
+		},
+		{
+			name:     "converted to the unit of current",
+			commands: []string{"mode sigfig", "add 1.000 m", "add 25 cm"},
+			want:     "1.25 m",
+		},
+		{
+			name:     "figures",
+			commands: []string{"mode sigfig", "add 0.0450", "figures"},
+			want:     "0.0450 has 3 significant figures",
+		},
+		{
+			name:     "exact figures",
+			commands: []string{"mode sigfig", "add 1/4", "figures"},
+			want:     "0.25 is exact",
+		},
+		{
+			name:     "standard mode shows every digit",
+			commands: []string{"add 2.50", "multiply 1.2"},
+			want:     "3.00",
+		},
+		{
+			name:     "figures with an argument",
+			commands: []string{"figures 3"},
+			wantErr:  errInvalidInput.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleAll(t, tt.commands)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+// handler of the stats mode. every number entered alone is pushed to the dataset of the calculator,
+// several numbers can be entered at once separated by spaces, and the commands describe the dataset.
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/atthoriq/calculator-project/stats"
+)
+
+const (
+	meanOp       = "mean"
+	medianOp     = "median"
+	statsModeOp  = "mode"
+	stddevOp     = "stddev"
+	varianceOp   = "variance"
+	minOp        = "min"
+	maxOp        = "max"
+	sumOp        = "sum"
+	countOp      = "count"
+	percentileOp = "percentile"
+	clearData    = "clear-data"
+
+	sample     = "sample"
+	population = "pop"
+)
+
+var statsCommands = map[string]bool{
+	meanOp: true, medianOp: true, stddevOp: true, varianceOp: true, minOp: true, maxOp: true,
+	sumOp: true, countOp: true, percentileOp: true, clearData: true,
+	pointOp: true, linregOp: true, polyfitOp: true, expfitOp: true, predictOp: true,
+}
+
+// isStats tells whether the command belongs to the stats mode: a stats command or a list of numbers.
+// mode alone is the statistical mode, mode with an argument still switches the mode
+func (ch *calculatorHandler) isStats(op string, args []string) bool {
+	if statsCommands[op] || (op == statsModeOp && len(args) == 0) {
+		return true
+	}
+
+	_, err := ch.parseValues(append([]string{op}, args...), 1, -1)
+	return err == nil
+}
+
+// handleStats handles the commands of the stats mode
+func (ch *calculatorHandler) handleStats(op string, args []string) (string, error) {
+	data := ch.calculator.GetData()
+
+	switch op {
+	case pointOp, linregOp, polyfitOp, expfitOp, predictOp:
+		return ch.handleRegression(op, args)
+	case meanOp, medianOp, minOp, maxOp:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
+		res, err := map[string]func([]float64) (float64, error){
+			meanOp:   stats.Mean,
+			medianOp: stats.Median,
+			minOp:    stats.Min,
+			maxOp:    stats.Max,
+		}[op](data)
+		if err != nil {
+			return "", err
+		}
+		return ch.format(res), nil
+	case stddevOp, varianceOp:
+		if len(args) > 1 {
+			return "", errInvalidInput
+		}
+		isSample := true
+		if len(args) == 1 {
+			if args[0] != sample && args[0] != population {
+				return "", fmt.Errorf("unknown %s %q: use %s or %s", op, args[0], sample, population)
+			}
+			isSample = args[0] == sample
+		}
+
+		compute := stats.Variance
+		if op == stddevOp {
+			compute = stats.StdDev
+		}
+		res, err := compute(data, isSample)
+		if err != nil {
+			return "", err
+		}
+		return ch.format(res), nil
+	case statsModeOp:
+		modes, err := stats.Mode(data)
+		if err != nil {
+			return "", err
+		}
+		if len(modes) == 0 {
+			return "no mode, every value appears once", nil
+		}
+
+		formatted := make([]string, len(modes))
+		for i, m := range modes {
+			formatted[i] = ch.format(m)
+		}
+		return strings.Join(formatted, " "), nil
+	case sumOp, countOp:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
+		if op == countOp {
+			return fmt.Sprint(len(data)), nil
+		}
+		return ch.format(stats.Sum(data)), nil
+	case percentileOp:
+		p, err := ch.parseOperand(args)
+		if err != nil {
+			return "", err
+		}
+		// percentile 90 and percentile 90% are the same
+		if p.percent {
+			p.value *= 100
+		}
+
+		res, err := stats.Percentile(data, p.value)
+		if err != nil {
+			return "", err
+		}
+		return ch.format(res), nil
+	case clearData:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
+		ch.calculator.ClearData()
+		ch.fit = nil
+		return "data cleared", nil
+	default:
+		values, err := ch.parseValues(append([]string{op}, args...), 1, -1)
+		if err != nil {
+			return "", err
+		}
+
+		for _, v := range values {
+			ch.calculator.Push(v)
+		}
+		return fmt.Sprintf("%d: %s", len(data)+len(values), ch.format(values[len(values)-1])), nil
+	}
+}
@@ -0,0 +1,215 @@
+package stats
+
+// least squares fits of paired data. the linear fit works on the deviations from the means,
+// the polynomial fit solves the least squares problem through a Householder QR decomposition
+// instead of the normal equations, which square the condition number of the problem.
+
+import (
+	"fmt"
+	"math"
+)
+
+// Fit is a curve fitted to paired data
+type Fit interface {
+	Predict(x float64) float64
+	// RSquared is the coefficient of determination of y, 1 being a perfect fit
+	RSquared() float64
+}
+
+// Linear is y = Intercept + Slope*x
+type Linear struct {
+	Slope     float64
+	Intercept float64
+	R2        float64
+}
+
+// Polynomial is y = Coefficients[0] + Coefficients[1]*x + Coefficients[2]*x^2 ...
+type Polynomial struct {
+	Coefficients []float64
+	R2           float64
+}
+
+// Exponential is y = A*e^(B*x)
+type Exponential struct {
+	A  float64
+	B  float64
+	R2 float64
+}
+
+func (l Linear) Predict(x float64) float64 { return l.Intercept + l.Slope*x }
+func (l Linear) RSquared() float64         { return l.R2 }
+
+func (p Polynomial) Predict(x float64) float64 {
+	var y float64
+	for i := len(p.Coefficients) - 1; i >= 0; i-- {
+		y = y*x + p.Coefficients[i]
+	}
+	return y
+}
+
+func (p Polynomial) RSquared() float64 { return p.R2 }
+
+func (e Exponential) Predict(x float64) float64 { return e.A * math.Exp(e.B*x) }
+func (e Exponential) RSquared() float64         { return e.R2 }
+
+// LinearFit fits a line through the points (xs[i], ys[i])
+func LinearFit(xs, ys []float64) (Linear, error) {
+	if err := checkPoints(xs, ys, 2); err != nil {
+		return Linear{}, err
+	}
+
+	meanX, _ := welford(xs)
+	meanY, _ := welford(ys)
+	var sxx, sxy float64
+	for i := range xs {
+		dx := xs[i] - meanX
+		sxx += dx * dx
+		sxy += dx * (ys[i] - meanY)
+	}
+	if sxx == 0 {
+		return Linear{}, fmt.Errorf("linear fit needs at least 2 distinct x")
+	}
+
+	l := Linear{Slope: sxy / sxx}
+	l.Intercept = meanY - l.Slope*meanX
+	l.R2 = rSquared(l, xs, ys)
+	return l, nil
+}
+
+// PolynomialFit fits a polynomial of degree through the points (xs[i], ys[i])
+func PolynomialFit(xs, ys []float64, degree int) (Polynomial, error) {
+	if degree < 1 {
+		return Polynomial{}, fmt.Errorf("invalid degree %d: it has to be at least 1", degree)
+	}
+	if err := checkPoints(xs, ys, degree+1); err != nil {
+		return Polynomial{}, err
+	}
+
+	// vandermonde matrix, a[i][j] = xs[i]^j
+	m := degree + 1
+	a := make([][]float64, len(xs))
+	for i, x := range xs {
+		a[i] = make([]float64, m)
+		a[i][0] = 1
+		for j := 1; j < m; j++ {
+			a[i][j] = a[i][j-1] * x
+		}
+	}
+
+	coefficients, err := leastSquares(a, append([]float64(nil), ys...))
+	if err != nil {
+		return Polynomial{}, fmt.Errorf("polynomial fit of degree %d needs at least %d distinct x", degree, m)
+	}
+
+	p := Polynomial{Coefficients: coefficients}
+	p.R2 = rSquared(p, xs, ys)
+	return p, nil
+}
+
+// ExponentialFit fits y = a*e^(b*x) by a linear fit of ln(y), every y has to be positive.
+// the R2 is computed on y, not on ln(y)
+func ExponentialFit(xs, ys []float64) (Exponential, error) {
+	if err := checkPoints(xs, ys, 2); err != nil {
+		return Exponential{}, err
+	}
+
+	logs := make([]float64, len(ys))
+	for i, y := range ys {
+		if y <= 0 {
+			return Exponential{}, fmt.Errorf("exponential fit needs positive y, got %v", y)
+		}
+		logs[i] = math.Log(y)
+	}
+
+	l, err := LinearFit(xs, logs)
+	if err != nil {
+		return Exponential{}, err
+	}
+
+	e := Exponential{A: math.Exp(l.Intercept), B: l.Slope}
+	e.R2 = rSquared(e, xs, ys)
+	return e, nil
+}
+
+func checkPoints(xs, ys []float64, min int) error {
+	if len(xs) != len(ys) {
+		return fmt.Errorf("%d x for %d y", len(xs), len(ys))
+	}
+	if len(xs) == 0 {
+		return ErrEmpty
+	}
+	if len(xs) < min {
+		return fmt.Errorf("the fit needs at least %d points, got %d", min, len(xs))
+	}
+	return nil
+}
+
+// rSquared is 1 - SSres/SStot of the fit, NaN when every y is the same
+func rSquared(f Fit, xs, ys []float64) float64 {
+	_, ssTot := welford(ys)
+	var ssRes float64
+	for i := range xs {
+		r := ys[i] - f.Predict(xs[i])
+		ssRes += r * r
+	}
+	if ssTot == 0 {
+		return math.NaN()
+	}
+	return 1 - ssRes/ssTot
+}
+
+// leastSquares solves min |a*x - b| for x by reducing a to an upper triangular r with Householder reflections.
+// a and b are overwritten
+func leastSquares(a [][]float64, b []float64) ([]float64, error) {
+	n, m := len(a), len(a[0])
+	for k := 0; k < m; k++ {
+		var norm float64
+		for i := k; i < n; i++ {
+			norm = math.Hypot(norm, a[i][k])
+		}
+		if norm == 0 {
+			return nil, fmt.Errorf("rank deficient")
+		}
+
+		// v = a[k:,k] - alpha*e1, alpha having the opposite sign of a[k][k] to avoid cancellation
+		alpha := -math.Copysign(norm, a[k][k])
+		v := make([]float64, n-k)
+		for i := k; i < n; i++ {
+			v[i-k] = a[i][k]
+		}
+		v[0] -= alpha
+		var vv float64
+		for _, vi := range v {
+			vv += vi * vi
+		}
+
+		reflect := func(col func(i int) *float64) {
+			var dot float64
+			for i := k; i < n; i++ {
+				dot += v[i-k] * *col(i)
+			}
+			s := 2 * dot / vv
+			for i := k; i < n; i++ {
+				*col(i) -= s * v[i-k]
+			}
+		}
+		for j := k; j < m; j++ {
+			reflect(func(i int) *float64 { return &a[i][j] })
+		}
+		reflect(func(i int) *float64 { return &b[i] })
+	}
+
+	// back substitution of r*x = b[:m]
+	x := make([]float64, m)
+	for k := m - 1; k >= 0; k-- {
+		if math.Abs(a[k][k]) <= 1e-12*math.Abs(a[0][0]) {
+			return nil, fmt.Errorf("rank deficient")
+		}
+		s := b[k]
+		for j := k + 1; j < m; j++ {
+			s -= a[k][j] * x[j]
+		}
+		x[k] = s / a[k][k]
+	}
+	return x, nil
+}
@@ -0,0 +1,64 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinearFit(t *testing.T) {
+	l, err := LinearFit([]float64{1, 2, 3, 4, 5}, []float64{2, 4, 5, 4, 5})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.6, l.Slope, 1e-12)
+	assert.InDelta(t, 2.2, l.Intercept, 1e-12)
+	assert.InDelta(t, 0.6, l.R2, 1e-12)
+	assert.InDelta(t, 5.8, l.Predict(6), 1e-12)
+
+	// the deviations from the means keep the slope exact despite the offset of x
+	l, err = LinearFit([]float64{1e9 + 1, 1e9 + 2, 1e9 + 3}, []float64{3, 5, 7})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), l.Slope)
+	assert.Equal(t, float64(1), l.R2)
+
+	_, err = LinearFit([]float64{1, 1}, []float64{1, 2})
+	assert.Error(t, err)
+	_, err = LinearFit([]float64{1}, []float64{1})
+	assert.Error(t, err)
+}
+
+func TestPolynomialFit(t *testing.T) {
+	xs := []float64{-2, -1, 0, 1, 2, 3}
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = 1 - 2*x + 3*x*x
+	}
+
+	p, err := PolynomialFit(xs, ys, 2)
+	assert.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{1, -2, 3}, p.Coefficients, 1e-9)
+	assert.InDelta(t, 1, p.R2, 1e-12)
+	assert.InDelta(t, 1-2*4+3*16.0, p.Predict(4), 1e-9)
+
+	_, err = PolynomialFit([]float64{1, 1, 1}, []float64{1, 2, 3}, 2)
+	assert.Error(t, err)
+	_, err = PolynomialFit(xs, ys, 0)
+	assert.Error(t, err)
+}
+
+func TestExponentialFit(t *testing.T) {
+	xs := []float64{0, 1, 2, 3}
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = 2 * math.Exp(0.5*x)
+	}
+
+	e, err := ExponentialFit(xs, ys)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2, e.A, 1e-12)
+	assert.InDelta(t, 0.5, e.B, 1e-12)
+	assert.InDelta(t, 1, e.R2, 1e-12)
+
+	_, err = ExponentialFit([]float64{0, 1}, []float64{1, -1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package stats
+
+// descriptive statistics of a dataset. they are computed with numerically stable algorithms:
+// sums are compensated (Neumaier) and the variance is accumulated with Welford's method,
+// so large offsets or long datasets don't lose the small differences between values.
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+var ErrEmpty = errors.New("no data")
+
+// Sum is the compensated sum of data
+func Sum(data []float64) float64 {
+	var sum, compensation float64
+	for _, x := range data {
+		t := sum + x
+		if math.Abs(sum) >= math.Abs(x) {
+			compensation += (sum - t) + x
+		} else {
+			compensation += (x - t) + sum
+		}
+		sum = t
+	}
+	return sum + compensation
+}
+
+func Mean(data []float64) (float64, error) {
+	if len(data) == 0 {
+		return 0, ErrEmpty
+	}
+
+	mean, _ := welford(data)
+	return mean, nil
+}
+
+// Variance is the sample variance of data, dividing by n-1, or the population variance dividing by n
+func Variance(data []float64, sample bool) (float64, error) {
+	n := len(data)
+	if n == 0 {
+		return 0, ErrEmpty
+	}
+	if sample && n < 2 {
+		return 0, fmt.Errorf("sample variance needs at least 2 values, got %d", n)
+	}
+
+	_, m2 := welford(data)
+	if sample {
+		return m2 / float64(n-1), nil
+	}
+	return m2 / float64(n), nil
+}
+
+// StdDev is the square root of the sample or population variance
+func StdDev(data []float64, sample bool) (float64, error) {
+	v, err := Variance(data, sample)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(v), nil
+}
+
+func Median(data []float64) (float64, error) {
+	return Percentile(data, 50)
+}
+
+// Mode is the most frequent values of data in ascending order. it's empty when every value appears once
+func Mode(data []float64) ([]float64, error) {
+	if len(data) == 0 {
+		return nil, ErrEmpty
+	}
+
+	sorted := sortedCopy(data)
+	var modes []float64
+	best := 1
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j] == sorted[i] {
+			j++
+		}
+		switch count := j - i; {
+		case count > best:
+			best, modes = count, []float64{sorted[i]}
+		case count == best && best > 1:
+			modes = append(modes, sorted[i])
+		}
+		i = j
+	}
+	return modes, nil
+}
+
+func Min(data []float64) (float64, error) {
+	if len(data) == 0 {
+		return 0, ErrEmpty
+	}
+
+	min := data[0]
+	for _, x := range data[1:] {
+		min = math.Min(min, x)
+	}
+	return min, nil
+}
+
+func Max(data []float64) (float64, error) {
+	if len(data) == 0 {
+		return 0, ErrEmpty
+	}
+
+	max := data[0]
+	for _, x := range data[1:] {
+		max = math.Max(max, x)
+	}
+	return max, nil
+}
+
+// Percentile is the p-th percentile of data, 0 <= p <= 100, interpolating linearly between
+// the closest ranks like spreadsheets' PERCENTILE.INC
+func Percentile(data []float64, p float64) (float64, error) {
+	if len(data) == 0 {
+		return 0, ErrEmpty
+	}
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, fmt.Errorf("invalid percentile %v: it has to be between 0 and 100", p)
+	}
+
+	sorted := sortedCopy(data)
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	if lo == len(sorted)-1 {
+		return sorted[lo], nil
+	}
+
+	// a + (b-a)*t keeps the result between a and b, unlike a*(1-t) + b*t
+	frac := rank - float64(lo)
+	return sorted[lo] + (sorted[lo+1]-sorted[lo])*frac, nil
+}
+
+// welford returns the mean and the sum of squared differences from the mean
+func welford(data []float64) (mean, m2 float64) {
+	for i, x := range data {
+		delta := x - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (x - mean)
+	}
+	return mean, m2
+}
+
+func sortedCopy(data []float64) []float64 {
+	sorted := append([]float64(nil), data...)
+	sort.Float64s(sorted)
+	return sorted
+}
@@ -0,0 +1,68 @@
+package stats
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescriptive(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	tests := []struct {
+		name  string
+		value func() (float64, error)
+		want  float64
+	}{
+		{name: "sum", value: func() (float64, error) { return Sum(data), nil }, want: 40},
+		{name: "mean", value: func() (float64, error) { return Mean(data) }, want: 5},
+		{name: "population variance", value: func() (float64, error) { return Variance(data, false) }, want: 4},
+		{name: "sample variance", value: func() (float64, error) { return Variance(data, true) }, want: 32.0 / 7},
+		{name: "population stddev", value: func() (float64, error) { return StdDev(data, false) }, want: 2},
+		{name: "median of even count", value: func() (float64, error) { return Median(data) }, want: 4.5},
+		{name: "median of odd count", value: func() (float64, error) { return Median([]float64{3, 1, 2}) }, want: 2},
+		{name: "min", value: func() (float64, error) { return Min(data) }, want: 2},
+		{name: "max", value: func() (float64, error) { return Max(data) }, want: 9},
+		{name: "percentile interpolates", value: func() (float64, error) { return Percentile([]float64{1, 2, 3, 4}, 25) }, want: 1.75},
+		{name: "percentile 100", value: func() (float64, error) { return Percentile(data, 100) }, want: 9},
+		{name: "percentile 0", value: func() (float64, error) { return Percentile(data, 0) }, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.value()
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-12)
+		})
+	}
+}
+
+func TestStability(t *testing.T) {
+	// the textbook sum of squares formula loses every digit of the variance with such an offset
+	data := []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}
+	v, err := Variance(data, true)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(30), v)
+
+	assert.Equal(t, float64(2), Sum([]float64{1, 1e100, 1, -1e100}))
+}
+
+func TestMode(t *testing.T) {
+	modes, err := Mode([]float64{1, 3, 2, 3, 1, 5})
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1, 3}, modes)
+
+	modes, err = Mode([]float64{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Empty(t, modes)
+}
+
+func TestErrors(t *testing.T) {
+	_, err := Mean(nil)
+	assert.True(t, errors.Is(err, ErrEmpty))
+
+	_, err = Variance([]float64{1}, true)
+	assert.Error(t, err)
+
+	_, err = Percentile([]float64{1}, 101)
+	assert.Error(t, err)
+}
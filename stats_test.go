@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_calculatorHandler_Handle_Stats(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "entering the mode",
+			commands: []string{"mode stats"},
+			want:     "mode stats: 0 values",
+		},
+		{
+			name:     "numbers are pushed",
+			commands: []string{"mode stats", "2", "4 4 4", "5 5 7 9"},
+			want:     "8: 9.00",
+		},
+		{
+			name:     "mean",
+			commands: []string{"mode stats", "2 4 4 4 5 5 7 9", "mean"},
+			want:     "5.00",
+		},
+		{
+			name:     "population stddev",
+			commands: []string{"mode stats", "2 4 4 4 5 5 7 9", "stddev pop"},
+			want:     "2.00",
+		},
+		{
+			name:     "sample variance",
+			commands: []string{"mode stats", "1 2 3 4", "variance"},
+			want:     "1.67",
+		},
+		{
+			name:     "statistical mode",
+			commands: []string{"mode stats", "1 3 2 3 1 5", "mode"},
+			want:     "1.00 3.00",
+		},
+		{
+			name:     "no statistical mode",
+			commands: []string{"mode stats", "1 2 3", "mode"},
+			want:     "no mode, every value appears once",
+		},
+		{
+			name:     "percentile as percentage",
+			commands: []string{"mode stats", "1 2 3 4", "percentile 25%"},
+			want:     "1.75",
+		},
+		{
+			name:     "count after clear-data",
+			commands: []string{"mode stats", "1 2 3", "clear-data", "count"},
+			want:     "0",
+		},
+		{
+			name:     "median of no data",
+			commands: []string{"mode stats", "median"},
+			wantErr:  true,
+		},
+		{
+			name:     "unknown variance kind",
+			commands: []string{"mode stats", "1 2", "variance both"},
+			wantErr:  true,
+		},
+		{
+			name:     "standard commands still work",
+			commands: []string{"mode stats", "1 2", "add 5"},
+			want:     "5.00",
+		},
+		{
+			name:     "the dataset is kept across modes",
+			commands: []string{"mode stats", "1 2", "mode std", "mode stats", "sum"},
+			want:     "3.00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleAll(t, tt.commands)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("calculatorHandler.Handle() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
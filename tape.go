@@ -0,0 +1,92 @@
+package main
+
+// tape formats the results as a printed adding-machine tape. every calculation becomes an entry
+// with its operand, the operator symbol and the running total right-aligned, so the output reads like a paper roll.
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	tapeOn    = "on"
+	tapeOff   = "off"
+	tapePrint = "print"
+
+	subtotalMarker = "S"
+	totalMarker    = "T"
+)
+
+// tapeSymbols is the operator symbol printed for each command, commands not listed are not put on the tape
+var tapeSymbols = map[string]string{
+	add:       "+",
+	subtract:  "-",
+	multiply:  "×",
+	divide:    "÷",
+	neg:       "+/-",
+	abs:       "|x|",
+	sqrt:      "√",
+	cbrt:      "∛",
+	sqr:       "x²",
+	cube:      "x³",
+	percentOf: "%",
+	pctChange: "Δ%",
+	markup:    "MU",
+	margin:    "MG",
+	discount:  "DSC",
+	gcd:       "GCD",
+	lcm:       "LCM",
+	nextPrime: "NP",
+	modPow:    "MP",
+	modInv:    "MI",
+	factorial: "n!",
+	gamma:     "Γ",
+	lgamma:    "lnΓ",
+	ncr:       "nCr",
+	npr:       "nPr",
+	binom:     "BIN",
+	convert:   "CNV",
+	repeat:    "R",
+	invert:    "INV",
+	importOp:  "IMP",
+	subtotal:  subtotalMarker,
+	cancel:    totalMarker,
+}
+
+type tape struct {
+	enabled bool
+	entries []string
+	// total is the running total of the last entry, printed by the total marker when the calculation is cancelled
+	total float64
+}
+
+// record puts the command on the tape and returns the printed entry.
+// it returns false when the command doesn't belong on the tape
+func (t *tape) record(op string, operand string, total float64, format func(float64) string) (string, bool) {
+	symbol, ok := tapeSymbols[op]
+	if !ok {
+		return "", false
+	}
+
+	if op == cancel {
+		// the total marker prints the total being cleared
+		total, t.total = t.total, 0
+	} else {
+		t.total = total
+	}
+
+	entry := fmt.Sprintf("%14s %-3s %16s", operand, symbol, format(total))
+	t.entries = append(t.entries, entry)
+	return entry, true
+}
+
+// print writes the whole tape
+func (t *tape) print(w io.Writer) error {
+	if len(t.entries) == 0 {
+		return nil
+	}
+
+	_, err := io.WriteString(w, strings.Join(t.entries, "\n")+"\n")
+	return err
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/atthoriq/calculator-project/calculator"
+)
+
+func Test_tape_record(t *testing.T) {
+	type args struct {
+		op      string
+		operand string
+		total   float64
+	}
+	tests := []struct {
+		name   string
+		before float64
+		args   args
+		want   string
+		wantOk bool
+	}{
+		{
+			name: "operand with symbol and running total",
+			args: args{
+				op:      add,
+				operand: "5.00",
+				total:   5,
+			},
+			want:   "          5.00 +               5.00",
+			wantOk: true,
+		},
+		{
+			name: "command without operand",
+			args: args{
+				op:    sqrt,
+				total: 3,
+			},
+			want:   "               √               3.00",
+			wantOk: true,
+		},
+		{
+			name: "repeat prints the steps as typed",
+			args: args{
+				op:      repeat,
+				operand: "2",
+				total:   1234.5,
+			},
+			want:   "             2 R            1234.50",
+			wantOk: true,
+		},
+		{
+			name:   "cancel prints the total being cleared",
+			before: 42,
+			args: args{
+				op:    cancel,
+				total: 0,
+			},
+			want:   "               T              42.00",
+			wantOk: true,
+		},
+		{
+			name: "command not on the tape",
+			args: args{
+				op: help,
+			},
+			want:   "",
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tp := &tape{enabled: true, total: tt.before}
+			got, ok := tp.record(tt.args.op, tt.args.operand, tt.args.total, numberFormat{}.format)
+			if ok != tt.wantOk {
+				t.Errorf("tape.record() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got != tt.want {
+				t.Errorf("tape.record() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_calculatorHandler_Handle_Tape(t *testing.T) {
+	ch := InitCalculatorHandler(calculator.InitNewCalculator())
+	commands := []string{"add 10", "tape on", "add 5", "multiply 2", "subtotal", "formula", "add 10%", "discount 20", "cancel", "tape off", "add 1"}
+	var outputs []string
+	for _, command := range commands {
+		got, err := ch.Handle(command)
+		if err != nil {
+			t.Fatalf("calculatorHandler.Handle(%q) error = %v", command, err)
+		}
+		outputs = append(outputs, got)
+	}
+
+	assert.Equal(t, []string{
+		"10.00",
+		"tape on",
+		"          5.00 +              15.00",
+		"          2.00 ×              30.00",
+		"               S              30.00",
+		"(x + 15) * 2",
+		"        10.00% +              33.00",
+		"         20.00 DSC            26.40",
+		"               T              26.40",
+		"tape off",
+		"1.00",
+	}, outputs)
+
+	file := filepath.Join(t.TempDir(), "tape.txt")
+	got, err := ch.Handle("tape print " + file)
+	assert.NoError(t, err)
+	assert.Equal(t, "6 tape entries written to "+file, got)
+
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	printed, err := ch.Handle("tape print")
+	assert.NoError(t, err)
+	assert.Equal(t, strings.TrimSuffix(string(content), "\n"), printed)
+	assert.Len(t, strings.Split(printed, "\n"), 6)
+}
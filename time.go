@@ -0,0 +1,201 @@
+package main
+
+// handler of the time mode. the running value is a chrono.Clock instead of the calculator, holding
+// an instant or a duration, and every result is shown as a date or as days, hours, minutes and seconds.
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/atthoriq/calculator-project/chrono"
+)
+
+const (
+	nowOp       = "now"
+	diff        = "diff"
+	workdays    = "workdays"
+	addWorkdays = "addworkdays"
+	holidaysOp  = "holidays"
+	tz          = "tz"
+	unix        = "unix"
+)
+
+// handleTime handles the commands of the time mode
+func (ch *calculatorHandler) handleTime(op string, args []string) (string, error) {
+	c := ch.clock
+
+	switch op {
+	case add, subtract:
+		v, err := ch.parseTime(args)
+		if err != nil {
+			return "", err
+		}
+
+		if op == add {
+			err = c.Add(v)
+		} else {
+			err = c.Subtract(v)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		return c.String(), nil
+	case multiply, divide:
+		o, err := ch.parseOperand(args)
+		if err != nil {
+			return "", err
+		}
+
+		f := o.value
+		if op == divide {
+			f = 1 / o.value
+		}
+		if err := c.Scale(f); err != nil {
+			return "", err
+		}
+
+		return c.String(), nil
+	case nowOp, cancel:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+
+		if op == nowOp {
+			v, _ := c.Parse(nowOp)
+			c.Set(v)
+		} else {
+			c.Set(chrono.Duration(0))
+		}
+
+		return c.String(), nil
+	case diff, workdays:
+		v, err := ch.parseTime(args)
+		if err != nil {
+			return "", err
+		}
+		if !v.IsInstant() {
+			return "", fmt.Errorf("%s takes a date, not the duration %s", op, v)
+		}
+
+		if op == diff {
+			p, err := c.Diff(v.Time())
+			if err != nil {
+				return "", err
+			}
+			return p.String(), nil
+		}
+
+		n, err := c.Workdays(v.Time())
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d business days", n), nil
+	case addWorkdays:
+		if len(args) != 1 {
+			return "", errInvalidInput
+		}
+
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return "", fmt.Errorf("%s is not a number of days", args[0])
+		}
+		if err := c.AddWorkdays(n); err != nil {
+			return "", err
+		}
+
+		return c.String(), nil
+	case holidaysOp:
+		switch len(args) {
+		case 0:
+			return formatHolidays(c.Holidays()), nil
+		case 1:
+			h, err := readHolidays(args[0])
+			if err != nil {
+				return "", err
+			}
+
+			c.SetHolidays(h)
+			return fmt.Sprintf("%d holidays loaded from %s", len(h), args[0]), nil
+		}
+
+		return "", errInvalidInput
+	case tz:
+		switch len(args) {
+		case 0:
+			return c.Location().String(), nil
+		case 1:
+			if err := c.SetLocation(args[0]); err != nil {
+				return "", err
+			}
+
+			return c.String(), nil
+		}
+
+		return "", errInvalidInput
+	case unix:
+		if len(args) > 0 {
+			return "", errInvalidInput
+		}
+		if !c.Value().IsInstant() {
+			return "", fmt.Errorf("current is the duration %s, not an instant", c)
+		}
+
+		return strconv.FormatInt(c.Value().Time().Unix(), 10), nil
+	default:
+		return "", fmt.Errorf("%s is not supported in time mode", op)
+	}
+}
+
+// parseTime reads an instant or a duration. the arguments are joined again, so 2026-10-18 14:30 is a single instant
+func (ch *calculatorHandler) parseTime(args []string) (chrono.Value, error) {
+	if len(args) == 0 {
+		return chrono.Value{}, errInvalidInput
+	}
+
+	return ch.clock.Parse(strings.Join(args, " "))
+}
+
+// enterTime creates the clock the first time the mode is entered, later the clock is found as it was left
+func (ch *calculatorHandler) enterTime() {
+	if ch.clock == nil {
+		ch.clock = chrono.New(time.Now)
+	}
+}
+
+func readHolidays(name string) (chrono.Holidays, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h, err := chrono.ReadHolidays(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return h, nil
+}
+
+// formatHolidays lists the holidays by date
+func formatHolidays(h chrono.Holidays) string {
+	if len(h) == 0 {
+		return "no holidays, load them with holidays <file>"
+	}
+
+	dates := make([]string, 0, len(h))
+	for date := range h {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	lines := make([]string, len(dates))
+	for i, date := range dates {
+		lines[i] = strings.TrimSpace(date + " " + h[date])
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.com/atthoriq/calculator-project/calculator"
+	"gitlab.com/atthoriq/calculator-project/chrono"
+)
+
+func Test_calculatorHandler_Handle_Time(t *testing.T) {
+	if _, err := time.LoadLocation("Asia/Jakarta"); err != nil {
+		t.Skip("no tz database")
+	}
+
+	holidays := filepath.Join(t.TempDir(), "holidays.txt")
+	if err := os.WriteFile(holidays, []byte("2026-12-25 Christmas Day\n2026-12-31\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "enter the mode",
+			commands: []string{"mode time"},
+			want:     "mode time: 0s",
+		},
+		{
+			name:     "date plus duration",
+			commands: []string{"add 2026-10-18", "add 1d12h"},
+			want:     "Mon 2026-10-19 12:00:00 UTC",
+		},
+		{
+			name:     "date and time typed with a space",
+			commands: []string{"add 2026-10-18 14:30", "subtract 45m"},
+			want:     "Sun 2026-10-18 13:45:00 UTC",
+		},
+		{
+			name:     "duration between instants",
+			commands: []string{"add 2026-10-20 18:00", "subtract 2026-10-18 09:30"},
+			want:     "2d 8h 30m",
+		},
+		{
+			name:     "scale a duration",
+			commands: []string{"add 1h30m", "multiply 3", "divide 2"},
+			want:     "2h 15m",
+		},
+		{
+			name:     "now",
+			commands: []string{"now"},
+			want:     "Sun 2026-10-18 09:00:00 UTC",
+		},
+		{
+			name:     "today",
+			commands: []string{"add today", "add 8h"},
+			want:     "Sun 2026-10-18 08:00:00 UTC",
+		},
+		{
+			name:     "timestamp",
+			commands: []string{"add @1760745600", "unix"},
+			want:     "1760745600",
+		},
+		{
+			name:     "diff",
+			commands: []string{"add 2025-08-15 10:00", "diff 2026-10-18 14:30"},
+			want:     "1y 2mo 3d 4h 30m",
+		},
+		{
+			name:     "workdays",
+			commands: []string{"add 2026-10-19", "workdays 2026-10-30"},
+			want:     "10 business days",
+		},
+		{
+			name:     "workdays skip holidays",
+			commands: []string{"holidays " + holidays, "add 2026-12-21", "workdays 2027-01-01"},
+			want:     "8 business days",
+		},
+		{
+			name:     "addworkdays",
+			commands: []string{"holidays " + holidays, "add 2026-12-24 17:00", "addworkdays 2"},
+			want:     "Tue 2026-12-29 17:00:00 UTC",
+		},
+		{
+			name:     "list holidays",
+			commands: []string{"holidays " + holidays, "holidays"},
+			want:     "2026-12-25 Christmas Day\n2026-12-31",
+		},
+		{
+			name:     "time zone conversion",
+			commands: []string{"add 2026-10-18 12:00", "tz Asia/Jakarta"},
+			want:     "Sun 2026-10-18 19:00:00 WIB",
+		},
+		{
+			name:     "dates are read in the time zone",
+			commands: []string{"tz America/New_York", "add 2026-10-18 12:00", "tz UTC"},
+			want:     "Sun 2026-10-18 16:00:00 UTC",
+		},
+		{
+			name:     "show the time zone",
+			commands: []string{"tz Europe/Paris", "tz"},
+			want:     "Europe/Paris",
+		},
+		{
+			name:     "unknown time zone",
+			commands: []string{"tz Mars/Olympus"},
+			wantErr:  `unknown time zone "Mars/Olympus"`,
+		},
+		{
+			name:     "instants can't be added",
+			commands: []string{"add 2026-10-18", "add 2026-10-19"},
+			wantErr:  chrono.ErrInstants.Error(),
+		},
+		{
+			name:     "plain number",
+			commands: []string{"add 5"},
+			wantErr:  `"5" is neither a date nor a duration, e.g. 2026-10-18, 2026-10-18 14:30 or 1h30m`,
+		},
+		{
+			name:     "diff from a duration",
+			commands: []string{"add 3h", "diff 2026-10-18"},
+			wantErr:  "current is a duration, add a date to it first",
+		},
+		{
+			name:     "unsupported command",
+			commands: []string{"sqrt"},
+			wantErr:  "sqrt is not supported in time mode",
+		},
+		{
+			name:     "cancel",
+			commands: []string{"add 2026-10-18", "cancel"},
+			want:     "0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := InitCalculatorHandler(calculator.InitNewCalculator())
+			ch.clock = chrono.New(func() time.Time { return time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC) })
+			ch.clock.SetLocation("UTC")
+			ch.mode = modeTime
+
+			got, err := handleEach(ch, tt.commands)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+// handler of the uncertain operands. an operand of add, subtract, multiply and divide may have a tolerance,
+// e.g. 10±0.5, 10+/-0.5 or 10±5%, and the results are shown with the bounds the calculator propagates.
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"gitlab.com/atthoriq/calculator-project/calculator"
+	"gitlab.com/atthoriq/calculator-project/interval"
+)
+
+const (
+	boundsOp    = "bounds"
+	boundsPM    = "pm"
+	boundsRange = "range"
+)
+
+var toleranceMarks = []string{"±", "+/-"}
+
+// parseUncertain reads an operand optionally followed by its tolerance. a tolerance in percent is relative to the operand
+func (ch *calculatorHandler) parseUncertain(args []string) (operand, error) {
+	joined := strings.Join(args, " ")
+	for _, mark := range toleranceMarks {
+		value, tolerance, found := strings.Cut(joined, mark)
+		if !found {
+			continue
+		}
+
+		v, err := ch.parseOperand(strings.Fields(value))
+		if err != nil {
+			return operand{}, err
+		}
+		if v.percent {
+			return operand{}, errors.New("a percent has no tolerance")
+		}
+
+		t, err := ch.parseOperand(strings.Fields(tolerance))
+		if err != nil {
+			return operand{}, err
+		}
+
+		v.tolerance = t.value
+		if t.percent {
+			v.tolerance = t.value * math.Abs(v.value)
+		}
+		if !(v.tolerance >= 0) {
+			return operand{}, fmt.Errorf("tolerance %s is not positive", strings.TrimSpace(tolerance))
+		}
+		return v, nil
+	}
+
+	return ch.parseOperand(args)
+}
+
+// within tells the calculator the operand v just queued is uncertain by tolerance
+func within(calc calculator.NewCalculator, v, tolerance float64) calculator.NewCalculator {
+	if tolerance == 0 {
+		return calc
+	}
+	return calc.Within(interval.Around(v, tolerance))
+}
+
+// formatBounded formats current with its bounds, as value±error or as range
+func (ch *calculatorHandler) formatBounded(v float64) string {
+	b := ch.calculator.GetBounds()
+	switch {
+	case b.IsPoint():
+		return ch.formatFigures(v)
+	case b.IsEmpty():
+		return ch.format(v) + " (no bounds)"
+	case ch.boundsStyle == boundsRange, math.IsNaN(v), math.IsInf(b.Radius(v), 0):
+		// a NaN or an unbounded current has no meaningful ± radius, the range still tells where it is
+		return ch.formatRange(b)
+	default:
+		return ch.format(v) + "±" + ch.format(b.Radius(v))
+	}
+}
+
+func (ch *calculatorHandler) formatRange(b interval.Interval) string {
+	if b.IsEmpty() {
+		return "[]"
+	}
+	return fmt.Sprintf("[%s, %s]", ch.format(b.Lo), ch.format(b.Hi))
+}
+
+// handleBounds shows the bounds of current or sets how results show them
+func (ch *calculatorHandler) handleBounds(args []string) (string, error) {
+	switch len(args) {
+	case 0:
+		return ch.formatRange(ch.calculator.GetBounds()), nil
+	case 1:
+		if args[0] != boundsPM && args[0] != boundsRange {
+			return "", fmt.Errorf("unknown bounds style %q: use pm or range", args[0])
+		}
+
+		ch.boundsStyle = args[0]
+		return "bounds " + args[0], nil
+	}
+
+	return "", errInvalidInput
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_calculatorHandler_Handle_Uncertainty(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "add an uncertain operand",
+			commands: []string{"add 10±0.5"},
+			want:     "10.00±0.50",
+		},
+		{
+			name:     "plus minus typed in ascii",
+			commands: []string{"add 10 +/- 0.5"},
+			want:     "10.00±0.50",
+		},
+		{
+			name:     "percent tolerance",
+			commands: []string{"add 10±5%"},
+			want:     "10.00±0.50",
+		},
+		{
+			name:     "errors sum up",
+			commands: []string{"add 10±0.5", "subtract 2±0.25"},
+			want:     "8.00±0.75",
+		},
+		{
+			name:     "multiply",
+			commands: []string{"add 10±0.5", "multiply 2±0.1"},
+			want:     "20.00±2.05",
+		},
+		{
+			name:     "certain operations keep the bounds",
+			commands: []string{"add 10±0.5", "multiply 3"},
+			want:     "30.00±1.50",
+		},
+		{
+			name:     "sqrt",
+			commands: []string{"add 16±9", "sqrt"},
+			want:     "4.00±1.35",
+		},
+		{
+			name:     "divide through zero",
+			commands: []string{"add 1", "divide 1±2"},
+			want:     "[-Inf, +Inf]",
+		},
+		{
+			name:     "sqrt of bounds through zero",
+			commands: []string{"add -1±2", "sqrt"},
+			want:     "[0.00, 1.00]",
+		},
+		{
+			name:     "range style",
+			commands: []string{"add 10±0.5", "bounds range", "add 1"},
+			want:     "[10.50, 11.50]",
+		},
+		{
+			name:     "show the bounds",
+			commands: []string{"add 10±0.5", "bounds"},
+			want:     "[9.50, 10.50]",
+		},
+		{
+			name:     "with a unit",
+			commands: []string{"add 10±0.5 m"},
+			want:     "10.00±0.50 m",
+		},
+		{
+			name:     "divide by 0 without uncertainty",
+			commands: []string{"add 1", "divide 0"},
+			want:     "NaN",
+		},
+		{
+			name:     "no bounds after divide by 0 with uncertainty",
+			commands: []string{"add 1±0.5", "divide 0"},
+			want:     "NaN (no bounds)",
+		},
+		{
+			name:     "cancel clears the bounds",
+			commands: []string{"add 10±0.5", "cancel", "add 1"},
+			want:     "1.00",
+		},
+		{
+			name:     "negative tolerance",
+			commands: []string{"add 10±-1"},
+			wantErr:  "tolerance -1 is not positive",
+		},
+		{
+			name:     "tolerance of a percent",
+			commands: []string{"add 10%±1"},
+			wantErr:  "a percent has no tolerance",
+		},
+		{
+			name:     "unknown style",
+			commands: []string{"bounds wide"},
+			wantErr:  `unknown bounds style "wide": use pm or range`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handleAll(t, tt.commands)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
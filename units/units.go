@@ -0,0 +1,402 @@
+package units
+
+// units of measurement. a unit is a product of named factors with integer exponents, e.g. km·h or m/s²,
+// and every factor knows its SI base dimension and its scale to SI. the factors are kept as written
+// instead of being reduced to SI, so km·h stays km·h and converting km to mi multiplies by mi/km.
+// temperatures with an offset (°C, °F) convert with their offset only when they stand alone.
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Dimension is the exponents of the SI base quantities: length, mass, time, electric current,
+// temperature, amount of substance and luminous intensity
+type Dimension [7]int
+
+var baseSymbols = [7]string{"m", "kg", "s", "A", "K", "mol", "cd"}
+
+var (
+	length      = Dimension{1}
+	mass        = Dimension{0, 1}
+	duration    = Dimension{0, 0, 1}
+	current     = Dimension{0, 0, 0, 1}
+	temperature = Dimension{0, 0, 0, 0, 1}
+	amount      = Dimension{0, 0, 0, 0, 0, 1}
+	luminosity  = Dimension{0, 0, 0, 0, 0, 0, 1}
+)
+
+// atom is a named unit that can be raised to a power
+type atom struct {
+	dim    Dimension
+	scale  float64
+	offset float64
+	// prefixable tells whether SI prefixes like k or m can be put in front of the symbol
+	prefixable bool
+}
+
+var atoms = map[string]atom{
+	"m":   {dim: length, scale: 1, prefixable: true},
+	"g":   {dim: mass, scale: 1e-3, prefixable: true},
+	"s":   {dim: duration, scale: 1, prefixable: true},
+	"A":   {dim: current, scale: 1, prefixable: true},
+	"K":   {dim: temperature, scale: 1, prefixable: true},
+	"mol": {dim: amount, scale: 1, prefixable: true},
+	"cd":  {dim: luminosity, scale: 1, prefixable: true},
+
+	"Hz": {dim: Dimension{0, 0, -1}, scale: 1, prefixable: true},
+	"N":  {dim: Dimension{1, 1, -2}, scale: 1, prefixable: true},
+	"Pa": {dim: Dimension{-1, 1, -2}, scale: 1, prefixable: true},
+	"J":  {dim: Dimension{2, 1, -2}, scale: 1, prefixable: true},
+	"W":  {dim: Dimension{2, 1, -3}, scale: 1, prefixable: true},
+	"Wh": {dim: Dimension{2, 1, -2}, scale: 3600, prefixable: true},
+	"C":  {dim: Dimension{0, 0, 1, 1}, scale: 1, prefixable: true},
+	"V":  {dim: Dimension{2, 1, -3, -1}, scale: 1, prefixable: true},
+	"L":  {dim: Dimension{3}, scale: 1e-3, prefixable: true},
+
+	"min": {dim: duration, scale: 60},
+	"h":   {dim: duration, scale: 3600},
+	"d":   {dim: duration, scale: 86400},
+	"wk":  {dim: duration, scale: 604800},
+	"t":   {dim: mass, scale: 1000},
+	"ha":  {dim: Dimension{2}, scale: 1e4},
+	"bar": {dim: Dimension{-1, 1, -2}, scale: 1e5},
+	"atm": {dim: Dimension{-1, 1, -2}, scale: 101325},
+	"cal": {dim: Dimension{2, 1, -2}, scale: 4.184},
+
+	"in":   {dim: length, scale: 0.0254},
+	"ft":   {dim: length, scale: 0.3048},
+	"yd":   {dim: length, scale: 0.9144},
+	"mi":   {dim: length, scale: 1609.344},
+	"nmi":  {dim: length, scale: 1852},
+	"acre": {dim: Dimension{2}, scale: 4046.8564224},
+	"oz":   {dim: mass, scale: 0.028349523125},
+	"lb":   {dim: mass, scale: 0.45359237},
+	"st":   {dim: mass, scale: 6.35029318},
+	"gal":  {dim: Dimension{3}, scale: 3.785411784e-3},
+	"mph":  {dim: Dimension{1, 0, -1}, scale: 0.44704},
+	"kn":   {dim: Dimension{1, 0, -1}, scale: 1852.0 / 3600},
+	"psi":  {dim: Dimension{-1, 1, -2}, scale: 6894.757293168361},
+	"kcal": {dim: Dimension{2, 1, -2}, scale: 4184},
+
+	"°C": {dim: temperature, scale: 1, offset: 273.15},
+	"°F": {dim: temperature, scale: 5.0 / 9, offset: 459.67 * 5 / 9},
+}
+
+// aliases are other spellings of the atoms
+var aliases = map[string]string{
+	"degC": "°C",
+	"degF": "°F",
+	"l":    "L",
+	"hr":   "h",
+	"sec":  "s",
+}
+
+var prefixes = map[string]float64{
+	"y": 1e-24, "z": 1e-21, "a": 1e-18, "f": 1e-15, "p": 1e-12, "n": 1e-9, "µ": 1e-6, "u": 1e-6, "m": 1e-3,
+	"c": 1e-2, "d": 1e-1, "da": 1e1, "h": 1e2, "k": 1e3, "M": 1e6, "G": 1e9, "T": 1e12, "P": 1e15, "E": 1e18,
+	"Z": 1e21, "Y": 1e24,
+}
+
+// factor is a named unit raised to exp
+type factor struct {
+	symbol string
+	exp    int
+	atom
+}
+
+// Unit is a product of factors in the order they were written. the zero value has no unit
+type Unit struct {
+	factors []factor
+}
+
+// None is the unit of a plain number
+var None = Unit{}
+
+// Parse reads a unit like km, m/s^2, m/s², kg·m²/s² or kg*m/s2. every / divides the term following it
+func Parse(s string) (Unit, error) {
+	if strings.TrimSpace(s) == "" {
+		return None, fmt.Errorf("empty unit")
+	}
+
+	u := None
+	divide := false
+	term := ""
+	flush := func() error {
+		if term == "" {
+			return fmt.Errorf("invalid unit %q: missing unit around an operator", s)
+		}
+		t, err := parseTerm(term)
+		if err != nil {
+			return fmt.Errorf("invalid unit %q: %w", s, err)
+		}
+		if divide {
+			t = t.Pow(-1)
+		}
+		u, term = u.Mul(t), ""
+		return nil
+	}
+
+	for _, r := range s {
+		switch r {
+		case '·', '*', '/':
+			if err := flush(); err != nil {
+				return None, err
+			}
+			divide = r == '/'
+		default:
+			term += string(r)
+		}
+	}
+	if err := flush(); err != nil {
+		return None, err
+	}
+	return u, nil
+}
+
+var superscripts = strings.NewReplacer("⁻", "-", "⁰", "0", "¹", "1", "²", "2", "³", "3", "⁴", "4", "⁵", "5", "⁶", "6", "⁷", "7", "⁸", "8", "⁹", "9")
+
+// parseTerm reads a symbol followed by an optional exponent, e.g. m, m2, m^2, m² or s⁻¹. 1 stands for no unit as in 1/s
+func parseTerm(term string) (Unit, error) {
+	if term == "1" {
+		return None, nil
+	}
+
+	symbol := strings.TrimRightFunc(term, func(r rune) bool {
+		return strings.ContainsRune("^-0123456789⁻⁰¹²³⁴⁵⁶⁷⁸⁹", r)
+	})
+	exp := 1
+	if rest := term[len(symbol):]; rest != "" {
+		e, err := strconv.Atoi(strings.TrimPrefix(superscripts.Replace(rest), "^"))
+		if err != nil || e == 0 {
+			return None, fmt.Errorf("invalid exponent %q", rest)
+		}
+		exp = e
+	}
+
+	symbol, a, err := lookup(symbol)
+	if err != nil {
+		return None, err
+	}
+	return Unit{[]factor{{symbol: symbol, exp: 1, atom: a}}}.Pow(exp), nil
+}
+
+// lookup finds the atom of symbol, trying the symbols as written before a prefix followed by a symbol.
+// it returns the symbol with aliases replaced, e.g. °C for degC
+func lookup(symbol string) (string, atom, error) {
+	if alias, ok := aliases[symbol]; ok {
+		symbol = alias
+	}
+	if a, ok := atoms[symbol]; ok {
+		return symbol, a, nil
+	}
+
+	for p, scale := range prefixes {
+		name, found := strings.CutPrefix(symbol, p)
+		if a, ok := atoms[name]; found && ok && a.prefixable {
+			a.scale *= scale
+			a.prefixable = false
+			return symbol, a, nil
+		}
+	}
+	return "", atom{}, fmt.Errorf("unknown unit %q", symbol)
+}
+
+// Mul is the product of u and v. the exponents of a symbol written in both add up
+func (u Unit) Mul(v Unit) Unit {
+	factors := append([]factor(nil), u.factors...)
+	for _, f := range v.factors {
+		i := slices.IndexFunc(factors, func(g factor) bool { return g.symbol == f.symbol })
+		if i < 0 {
+			factors = append(factors, f)
+			continue
+		}
+		factors[i].exp += f.exp
+	}
+
+	factors = slices.DeleteFunc(factors, func(f factor) bool { return f.exp == 0 })
+	if len(factors) == 0 {
+		return None
+	}
+	return Unit{factors}
+}
+
+// Div is the quotient of u by v
+func (u Unit) Div(v Unit) Unit {
+	return u.Mul(v.Pow(-1))
+}
+
+// Pow is u to the power of n
+func (u Unit) Pow(n int) Unit {
+	if n == 0 {
+		return None
+	}
+
+	factors := make([]factor, len(u.factors))
+	for i, f := range u.factors {
+		f.exp *= n
+		factors[i] = f
+	}
+	return Unit{factors}
+}
+
+// Root is the n-th root of u, every exponent has to be a multiple of n
+func (u Unit) Root(n int) (Unit, error) {
+	if n <= 0 {
+		return None, fmt.Errorf("invalid root %d", n)
+	}
+
+	factors := make([]factor, len(u.factors))
+	for i, f := range u.factors {
+		if f.exp%n != 0 {
+			return None, fmt.Errorf("root %d of %s has no unit", n, u)
+		}
+		f.exp /= n
+		factors[i] = f
+	}
+	return Unit{factors}, nil
+}
+
+func (u Unit) IsNone() bool {
+	return len(u.factors) == 0
+}
+
+// Dimension is the SI base dimension of u
+func (u Unit) Dimension() Dimension {
+	var d Dimension
+	for _, f := range u.factors {
+		for i := range d {
+			d[i] += f.dim[i] * f.exp
+		}
+	}
+	return d
+}
+
+// Scale is the factor converting a value of u to SI base units, offsets aside
+func (u Unit) Scale() float64 {
+	scale := 1.0
+	for _, f := range u.factors {
+		scale *= math.Pow(f.scale, float64(f.exp))
+	}
+	return scale
+}
+
+// offset is the SI value of 0 in u, only a temperature standing alone has one
+func (u Unit) offset() float64 {
+	if len(u.factors) == 1 && u.factors[0].exp == 1 {
+		return u.factors[0].offset
+	}
+	return 0
+}
+
+// Compatible tells whether a value of u can be converted to v
+func (u Unit) Compatible(v Unit) bool {
+	return u.Dimension() == v.Dimension()
+}
+
+// Convert converts the value v of unit from to unit to, taking the temperature offsets into account
+func Convert(v float64, from, to Unit) (float64, error) {
+	if !from.Compatible(to) {
+		return 0, incompatible(from, to)
+	}
+
+	return (v*from.Scale() + from.offset() - to.offset()) / to.Scale(), nil
+}
+
+// ConvertDifference converts the difference v of unit from to unit to. it ignores the temperature offsets,
+// a difference of 1 °C is a difference of 1 K
+func ConvertDifference(v float64, from, to Unit) (float64, error) {
+	if !from.Compatible(to) {
+		return 0, incompatible(from, to)
+	}
+
+	return v * from.Scale() / to.Scale(), nil
+}
+
+// Affine returns a and b so that converting a value x of from to is a*x + b
+func Affine(from, to Unit) (a, b float64, err error) {
+	if !from.Compatible(to) {
+		return 0, 0, incompatible(from, to)
+	}
+
+	return from.Scale() / to.Scale(), (from.offset() - to.offset()) / to.Scale(), nil
+}
+
+func incompatible(from, to Unit) error {
+	return fmt.Errorf("%s (%s) doesn't match %s (%s)", describe(from), from.Dimension(), describe(to), to.Dimension())
+}
+
+func describe(u Unit) string {
+	if u.IsNone() {
+		return "plain number"
+	}
+	return u.String()
+}
+
+// String writes the factors with a positive exponent first, e.g. kg·m/s²
+func (u Unit) String() string {
+	var num, den []string
+	for _, f := range u.factors {
+		if f.exp > 0 {
+			num = append(num, f.symbol+superscript(f.exp))
+		} else {
+			den = append(den, f.symbol+superscript(-f.exp))
+		}
+	}
+
+	s := strings.Join(num, "·")
+	if len(den) == 0 {
+		return s
+	}
+	if s == "" {
+		s = "1"
+	}
+	return s + "/" + strings.Join(den, "/")
+}
+
+var dimensionNames = map[Dimension]string{
+	{}:             "no dimension",
+	length:         "length",
+	mass:           "mass",
+	duration:       "time",
+	current:        "electric current",
+	temperature:    "temperature",
+	amount:         "amount of substance",
+	luminosity:     "luminous intensity",
+	{2}:            "area",
+	{3}:            "volume",
+	{1, 0, -1}:     "speed",
+	{1, 0, -2}:     "acceleration",
+	{0, 0, -1}:     "frequency",
+	{1, 1, -2}:     "force",
+	{-1, 1, -2}:    "pressure",
+	{2, 1, -2}:     "energy",
+	{2, 1, -3}:     "power",
+	{0, 0, 1, 1}:   "electric charge",
+	{2, 1, -3, -1}: "voltage",
+}
+
+// String names the dimension, or writes it in base units when it has no name, e.g. m·s for length times time
+func (d Dimension) String() string {
+	if name, ok := dimensionNames[d]; ok {
+		return name
+	}
+
+	var u Unit
+	for i, exp := range d {
+		if exp != 0 {
+			u.factors = append(u.factors, factor{symbol: baseSymbols[i], exp: exp})
+		}
+	}
+	return u.String()
+}
+
+func superscript(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return strings.NewReplacer("-", "⁻", "0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴", "5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹").Replace(strconv.Itoa(n))
+}
@@ -0,0 +1,104 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParse(t *testing.T, s string) Unit {
+	t.Helper()
+	u, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", s, err)
+	}
+	return u
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		scale float64
+		dim   string
+	}{
+		{input: "km", want: "km", scale: 1000, dim: "length"},
+		{input: "m/s^2", want: "m/s²", scale: 1, dim: "acceleration"},
+		{input: "m/s²", want: "m/s²", scale: 1, dim: "acceleration"},
+		{input: "kg*m2/s2", want: "kg·m²/s²", scale: 1, dim: "energy"},
+		{input: "1/s", want: "1/s", scale: 1, dim: "frequency"},
+		{input: "s⁻¹", want: "1/s", scale: 1, dim: "frequency"},
+		{input: "km·h", want: "km·h", scale: 3.6e6, dim: "m·s"},
+		{input: "kWh", want: "kWh", scale: 3.6e6, dim: "energy"},
+		{input: "mmol", want: "mmol", scale: 1e-3, dim: "amount of substance"},
+		{input: "degC", want: "°C", scale: 1, dim: "temperature"},
+		{input: "m/m", want: "", scale: 1, dim: "no dimension"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			u := mustParse(t, tt.input)
+			assert.Equal(t, tt.want, u.String())
+			assert.InDelta(t, tt.scale, u.Scale(), tt.scale*1e-12)
+			assert.Equal(t, tt.dim, u.Dimension().String())
+		})
+	}
+
+	for _, input := range []string{"", "furlong", "m/", "m^x", "kmi", "m^0"} {
+		_, err := Parse(input)
+		assert.Error(t, err, "Parse(%q)", input)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		from, to string
+		want     float64
+	}{
+		{name: "length", value: 5, from: "km", to: "mi", want: 3.1068559611866697},
+		{name: "speed", value: 100, from: "km/h", to: "m/s", want: 27.77777777777778},
+		{name: "celsius to fahrenheit", value: 100, from: "°C", to: "°F", want: 212},
+		{name: "fahrenheit to kelvin", value: 32, from: "°F", to: "K", want: 273.15},
+		{name: "energy", value: 1, from: "kWh", to: "MJ", want: 3.6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Convert(tt.value, mustParse(t, tt.from), mustParse(t, tt.to))
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+
+	d, err := ConvertDifference(10, mustParse(t, "°C"), mustParse(t, "°F"))
+	assert.NoError(t, err)
+	assert.InDelta(t, 18, d, 1e-12)
+
+	_, err = Convert(3, mustParse(t, "kg"), mustParse(t, "km"))
+	assert.EqualError(t, err, "kg (mass) doesn't match km (length)")
+}
+
+func TestAlgebra(t *testing.T) {
+	km, h := mustParse(t, "km"), mustParse(t, "h")
+	assert.Equal(t, "km·h", km.Mul(h).String())
+	assert.Equal(t, "km/h", km.Div(h).String())
+	assert.Equal(t, "km", km.Mul(h).Div(h).String())
+	assert.True(t, km.Div(km).IsNone())
+
+	// converting multiplies by the target over the source
+	mi := mustParse(t, "mi")
+	assert.Equal(t, "mi", km.Mul(mi.Div(km)).String())
+
+	area := km.Pow(2)
+	assert.Equal(t, "km²", area.String())
+	root, err := area.Root(2)
+	assert.NoError(t, err)
+	assert.Equal(t, "km", root.String())
+	_, err = area.Root(3)
+	assert.Error(t, err)
+
+	a, b, err := Affine(mustParse(t, "°C"), mustParse(t, "°F"))
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.8, a, 1e-12)
+	assert.InDelta(t, 32, b, 1e-12)
+}